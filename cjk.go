@@ -0,0 +1,393 @@
+package main
+
+import (
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// IME practice — Chinese and Japanese lessons
+// ════════════════════════════════════════════════════════════════════
+
+// cjkLessons are practised through an input method: by default the
+// committed characters are compared, with the pinyin, zhuyin or romaji
+// reading shown above each character. With Settings.Romanize the target
+// becomes the romanization itself, as typed into the IME.
+var cjkLessons = []Lesson{
+	{
+		Name:    "中文 1 — 常用字",
+		Lang:    "zh",
+		Grading: "chinese-exam",
+		Lines: []string{
+			"我们 你们 他们 大家",
+			"天 地 人 你 我 他",
+			"上 下 左 右 前 后",
+			"一 二 三 四 五 六",
+			"七 八 九 十 百 千",
+		},
+	},
+	{
+		Name:    "中文 2 — 词语",
+		Lang:    "zh",
+		Grading: "chinese-exam",
+		Lines: []string{
+			"中国 学生 老师 朋友",
+			"今天 明天 昨天 时间",
+			"工作 学习 生活 电脑",
+			"吃饭 喝水 上网 看书",
+		},
+	},
+	{
+		Name:    "中文 3 — 短句",
+		Lang:    "zh",
+		Grading: "chinese-exam",
+		Lines: []string{
+			"你好，世界。",
+			"我爱学习中文。",
+			"今天天气很好。",
+			"我们一起去吃饭吧。",
+			"熟能生巧，天天练习。",
+			"学而时习之。",
+		},
+	},
+	{
+		Name: "日本語 1 — ひらがな",
+		Lang: "ja",
+		Lines: []string{
+			"あいうえお かきくけこ",
+			"さしすせそ たちつてと",
+			"なにぬねの はひふへほ",
+			"まみむめも やゆよ",
+			"らりるれろ わをん",
+			"がぎぐげご ざじずぜぞ",
+			"きゃ きゅ きょ しゃ しゅ しょ",
+		},
+	},
+	{
+		Name: "日本語 2 — カタカナ",
+		Lang: "ja",
+		Lines: []string{
+			"アイウエオ カキクケコ",
+			"サシスセソ タチツテト",
+			"コーヒー テレビ パソコン",
+			"ラーメン カメラ ノート",
+			"ゲーム メール チョコレート",
+		},
+	},
+	{
+		Name: "日本語 3 — 短文",
+		Lang: "ja",
+		Lines: []string{
+			"ありがとう ございます",
+			"おはよう ございます",
+			"きょうは いい てんき です",
+			"がっこうに いきます",
+			"いっしょに たべましょう",
+			"よろしく おねがいします",
+		},
+	},
+}
+
+// pinyin gives the toneless reading of every hanzi in cjkLessons, as
+// typed into a pinyin IME. Polyphonic characters carry the reading used
+// in the bundled lessons.
+var pinyin = map[rune]string{
+	'我': "wo", '们': "men", '你': "ni", '他': "ta", '大': "da", '家': "jia",
+	'天': "tian", '地': "di", '人': "ren", '上': "shang", '下': "xia",
+	'左': "zuo", '右': "you", '前': "qian", '后': "hou",
+	'一': "yi", '二': "er", '三': "san", '四': "si", '五': "wu", '六': "liu",
+	'七': "qi", '八': "ba", '九': "jiu", '十': "shi", '百': "bai", '千': "qian",
+	'中': "zhong", '国': "guo", '学': "xue", '生': "sheng", '老': "lao",
+	'师': "shi", '朋': "peng", '友': "you", '今': "jin", '明': "ming",
+	'昨': "zuo", '时': "shi", '间': "jian", '工': "gong", '作': "zuo",
+	'习': "xi", '活': "huo", '电': "dian", '脑': "nao", '吃': "chi",
+	'饭': "fan", '喝': "he", '水': "shui", '网': "wang", '看': "kan",
+	'书': "shu", '好': "hao", '世': "shi", '界': "jie", '爱': "ai",
+	'文': "wen", '气': "qi", '很': "hen", '起': "qi", '去': "qu", '吧': "ba",
+	'熟': "shu", '能': "neng", '巧': "qiao", '练': "lian", '而': "er", '之': "zhi",
+}
+
+// kana gives the Hepburn romaji of each hiragana, spelled the way it is
+// typed into a romaji IME (は is "ha", を is "wo").
+var kana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'を': "wo", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "xa", 'ぃ': "xi", 'ぅ': "xu", 'ぇ': "xe", 'ぉ': "xo",
+	'ー': "-",
+}
+
+// yoon maps the small ya/yu/yo to the vowel they contribute.
+var yoon = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+// hiragana folds katakana onto hiragana so one table serves both.
+func hiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヴ' {
+		return r - 0x60
+	}
+	return r
+}
+
+func firstRune(s string) rune {
+	for _, r := range s {
+		return r
+	}
+	return 0
+}
+
+// rubyUnit is one reading unit: n grapheme clusters read as ruby.
+type rubyUnit struct {
+	n    int
+	ruby string
+}
+
+// rubyUnits groups the clusters of a line into reading units with their
+// romanization. Clusters without a reading (spaces, punctuation) form
+// units with an empty ruby.
+func rubyUnits(lang string, cl []string) []rubyUnit {
+	var units []rubyUnit
+	for i := 0; i < len(cl); {
+		u := rubyUnit{n: 1}
+		switch lang {
+		case "zh":
+			u.ruby = pinyin[firstRune(cl[i])]
+		case "ja":
+			u = kanaUnit(cl, i)
+		}
+		units = append(units, u)
+		i += u.n
+	}
+	return units
+}
+
+// kanaUnit reads the kana unit starting at cl[i]: a single kana, a kana
+// with a small ya/yu/yo (きゃ kya), or the small tsu, which doubles the
+// consonant that follows it (っこ kko).
+func kanaUnit(cl []string, i int) rubyUnit {
+	r := hiragana(firstRune(cl[i]))
+	if r == 'っ' {
+		next := ""
+		if i+1 < len(cl) {
+			next = kanaUnit(cl, i+1).ruby
+		}
+		switch {
+		case strings.HasPrefix(next, "ch"):
+			return rubyUnit{1, "t"}
+		case next != "" && !strings.ContainsAny(next[:1], "aiueon-"):
+			return rubyUnit{1, next[:1]}
+		}
+		return rubyUnit{1, "xtsu"}
+	}
+	base, ok := kana[r]
+	if !ok {
+		return rubyUnit{1, ""}
+	}
+	if i+1 < len(cl) {
+		if v, ok := yoon[hiragana(firstRune(cl[i+1]))]; ok && strings.HasSuffix(base, "i") && len(base) > 1 {
+			stem := base[:len(base)-1]
+			if stem == "sh" || stem == "ch" || stem == "j" {
+				return rubyUnit{2, stem + v}
+			}
+			return rubyUnit{2, stem + "y" + v}
+		}
+	}
+	return rubyUnit{1, base}
+}
+
+// cjkPunct maps full-width punctuation to what is typed in its place.
+var cjkPunct = map[string]string{
+	"，": ", ", "、": ", ", "。": ". ", "！": "! ", "？": "? ",
+	"：": ": ", "；": "; ", "　": " ",
+}
+
+// romanize spells a lesson line the way it is keyed into the IME:
+// syllables of a word run together, spaces and punctuation carry over.
+func romanize(lang, line string) string {
+	cl := lineClusters(line)
+	var b strings.Builder
+	k := 0
+	for _, u := range rubyUnits(lang, cl) {
+		switch {
+		case u.ruby != "":
+			b.WriteString(u.ruby)
+		case cjkPunct[cl[k]] != "":
+			b.WriteString(cjkPunct[cl[k]])
+		default:
+			b.WriteString(strings.Join(cl[k:k+u.n], ""))
+		}
+		k += u.n
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// practiceLesson returns the lesson to type for l under these
+// settings: the romanized form when Romanize is on, and fresh lines
+// for a generated lesson.
+func (st *Settings) practiceLesson(l *Lesson) *Lesson {
+	if l.next != nil {
+		n := l.next(st)
+		n.next = l.next
+		return n
+	}
+	if l.Lang == "" || !st.Romanize {
+		return l
+	}
+	r := &Lesson{Name: l.Name + " (" + romanName(l.Lang) + ")", Lang: l.Lang, Orig: l.Lines}
+	for _, line := range l.Lines {
+		r.Lines = append(r.Lines, romanize(l.Lang, line))
+	}
+	return r
+}
+
+func romanName(lang string) string {
+	if lang == "ja" {
+		return "romaji"
+	}
+	return "pinyin"
+}
+
+// ════════════════════════════════════════════════════════════════════
+// Zhuyin (bopomofo)
+// ════════════════════════════════════════════════════════════════════
+
+var zhuyinInitials = []struct{ py, zy string }{
+	{"zh", "ㄓ"}, {"ch", "ㄔ"}, {"sh", "ㄕ"},
+	{"b", "ㄅ"}, {"p", "ㄆ"}, {"m", "ㄇ"}, {"f", "ㄈ"},
+	{"d", "ㄉ"}, {"t", "ㄊ"}, {"n", "ㄋ"}, {"l", "ㄌ"},
+	{"g", "ㄍ"}, {"k", "ㄎ"}, {"h", "ㄏ"},
+	{"j", "ㄐ"}, {"q", "ㄑ"}, {"x", "ㄒ"},
+	{"r", "ㄖ"}, {"z", "ㄗ"}, {"c", "ㄘ"}, {"s", "ㄙ"},
+}
+
+// zhuyinFinals is keyed by the final in full form, with ü written v.
+var zhuyinFinals = map[string]string{
+	"a": "ㄚ", "o": "ㄛ", "e": "ㄜ", "ai": "ㄞ", "ei": "ㄟ", "ao": "ㄠ", "ou": "ㄡ",
+	"an": "ㄢ", "en": "ㄣ", "ang": "ㄤ", "eng": "ㄥ", "er": "ㄦ", "ong": "ㄨㄥ",
+	"i": "ㄧ", "ia": "ㄧㄚ", "ie": "ㄧㄝ", "iao": "ㄧㄠ", "iou": "ㄧㄡ", "iu": "ㄧㄡ",
+	"ian": "ㄧㄢ", "in": "ㄧㄣ", "iang": "ㄧㄤ", "ing": "ㄧㄥ", "iong": "ㄩㄥ",
+	"u": "ㄨ", "ua": "ㄨㄚ", "uo": "ㄨㄛ", "uai": "ㄨㄞ", "uei": "ㄨㄟ", "ui": "ㄨㄟ",
+	"uan": "ㄨㄢ", "uen": "ㄨㄣ", "un": "ㄨㄣ", "uang": "ㄨㄤ", "ueng": "ㄨㄥ",
+	"v": "ㄩ", "ve": "ㄩㄝ", "van": "ㄩㄢ", "vn": "ㄩㄣ",
+}
+
+// pinyinToZhuyin converts one toneless pinyin syllable to zhuyin.
+// It returns "" for spellings it does not know.
+func pinyinToZhuyin(py string) string {
+	// y- and w- only spell a medial; restore it
+	switch {
+	case strings.HasPrefix(py, "yu"):
+		py = "v" + py[2:]
+	case strings.HasPrefix(py, "yi"), strings.HasPrefix(py, "wu"):
+		py = py[1:]
+	case strings.HasPrefix(py, "y"):
+		py = "i" + py[1:]
+	case strings.HasPrefix(py, "w"):
+		py = "u" + py[1:]
+	}
+	initial, zy := "", ""
+	for _, in := range zhuyinInitials {
+		if strings.HasPrefix(py, in.py) {
+			initial, zy = in.py, in.zy
+			break
+		}
+	}
+	final := py[len(initial):]
+	switch initial {
+	case "j", "q", "x":
+		if strings.HasPrefix(final, "u") {
+			final = "v" + final[1:]
+		}
+	case "zh", "ch", "sh", "r", "z", "c", "s":
+		if final == "i" {
+			return zy // zhi, chi, shi, ri, zi, ci, si
+		}
+	}
+	f, ok := zhuyinFinals[final]
+	if !ok {
+		return ""
+	}
+	return zy + f
+}
+
+// ════════════════════════════════════════════════════════════════════
+// Rendering helpers
+// ════════════════════════════════════════════════════════════════════
+
+// displayRuby is the reading shown above a unit: zhuyin for Chinese
+// when Zhuyin is on, the romanization otherwise.
+func (st *Settings) displayRuby(lang, ruby string) string {
+	if lang == "zh" && st.Zhuyin && ruby != "" {
+		if zy := pinyinToZhuyin(ruby); zy != "" {
+			return zy
+		}
+	}
+	return ruby
+}
+
+// rubyRows lays out the current line of an IME lesson as three aligned
+// rows: readings, target characters and the colour-coded input. Each
+// reading unit gets a cell as wide as the wider of its text and its
+// reading plus a space.
+func (st *Settings) rubyRows(s *Session) (ruby, target, input string) {
+	var rb, tb, ib strings.Builder
+	k := 0
+	for _, u := range rubyUnits(s.lesson.Lang, s.target) {
+		r := st.displayRuby(s.lesson.Lang, u.ruby)
+		w := 0
+		for i := k; i < k+u.n; i++ {
+			w += clusterWidth(s.target[i])
+		}
+		cell := w
+		if r != "" && vLen(r)+1 > cell {
+			cell = vLen(r) + 1
+		}
+		rb.WriteString(r + strings.Repeat(" ", cell-vLen(r)))
+		for i := k; i < k+u.n; i++ {
+			tb.WriteString(s.target[i])
+			if c := inputCell(s, i); c != "" {
+				ib.WriteString(c)
+			} else {
+				ib.WriteString(strings.Repeat(" ", clusterWidth(s.target[i])))
+			}
+		}
+		tb.WriteString(strings.Repeat(" ", cell-w))
+		ib.WriteString(strings.Repeat(" ", cell-w))
+		k += u.n
+	}
+	return rb.String(), tb.String(), ib.String()
+}
+
+// trackIsCJK reports whether any lesson of t is an IME lesson.
+func trackIsCJK(t *Track) bool {
+	for _, l := range t.Lessons {
+		if l.Lang != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (st *Settings) imeModeName() string {
+	if st.Romanize {
+		return "Pinyin/Romaji"
+	}
+	return "IME Characters"
+}
+
+func (st *Settings) rubyName() string {
+	if st.Zhuyin {
+		return "Zhuyin"
+	}
+	return "Pinyin"
+}
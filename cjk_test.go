@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct{ lang, in, want string }{
+		{"zh", "我们 你们", "women nimen"},
+		{"zh", "你好，世界。", "nihao, shijie."},
+		{"ja", "きょうは いい てんき です", "kyouha ii tenki desu"},
+		{"ja", "いっしょに たべましょう", "isshoni tabemashou"},
+		{"ja", "チョコレート", "chokore-to"},
+		{"ja", "がっこう", "gakkou"},
+	}
+	for _, c := range cases {
+		if got := romanize(c.lang, c.in); got != c.want {
+			t.Errorf("romanize(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestPinyinToZhuyin(t *testing.T) {
+	cases := map[string]string{
+		"wo": "ㄨㄛ", "shi": "ㄕ", "xue": "ㄒㄩㄝ", "you": "ㄧㄡ", "yi": "ㄧ",
+		"zhong": "ㄓㄨㄥ", "jiu": "ㄐㄧㄡ", "qu": "ㄑㄩ", "shui": "ㄕㄨㄟ", "er": "ㄦ",
+	}
+	for py, want := range cases {
+		if got := pinyinToZhuyin(py); got != want {
+			t.Errorf("pinyinToZhuyin(%q) = %q, want %q", py, got, want)
+		}
+	}
+	for _, l := range cjkLessons {
+		for _, line := range l.Lines {
+			cl := lineClusters(line)
+			k := 0
+			for _, u := range rubyUnits(l.Lang, cl) {
+				if u.ruby == "" && unicode.In(firstRune(cl[k]), unicode.Han, unicode.Hiragana, unicode.Katakana) {
+					t.Errorf("%s: no reading for %q", l.Name, cl[k])
+				}
+				if l.Lang == "zh" && u.ruby != "" && pinyinToZhuyin(u.ruby) == "" {
+					t.Errorf("%s: no zhuyin for %q", l.Name, u.ruby)
+				}
+				k += u.n
+			}
+		}
+	}
+}
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Classroom — `tt classroom`, a teacher's view of the class's results
+// ════════════════════════════════════════════════════════════════════
+
+// classroomDefaultPort is where `tt classroom` listens for students.
+const classroomDefaultPort = "7778"
+
+const (
+	weakWindow = 10 // recent lessons the weakest keys are taken from
+	weakKeys   = 3  // weakest keys shown per student
+)
+
+// classReport is sent to the classroom by a student's tt, started
+// with -classroom, after every finished lesson.
+type classReport struct {
+	Student string `json:"student"`
+	Result  Result `json:"result"`
+}
+
+// sendReport delivers one report to the classroom at addr.
+func sendReport(addr string, rep classReport) error {
+	conn, err := net.DialTimeout("tcp", withDefaultPort(addr, classroomDefaultPort), 5*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	return json.NewEncoder(conn).Encode(rep)
+}
+
+// student is the name the ui reports results under: the profile, else
+// -name.
+func (u *ui) student() string {
+	if u.profile != nil {
+		return u.profile.Name
+	}
+	return *nameFlag
+}
+
+// class holds every student's results, oldest first.
+type class struct {
+	mu       sync.Mutex
+	students map[string][]Result
+	changed  chan struct{} // signalled when results arrive
+}
+
+func newClass() *class {
+	return &class{students: map[string][]Result{}, changed: make(chan struct{}, 1)}
+}
+
+func (c *class) notify() {
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
+
+// add appends results of a student.
+func (c *class) add(name string, rs ...Result) {
+	c.mu.Lock()
+	c.students[name] = append(c.students[name], rs...)
+	c.mu.Unlock()
+	c.notify()
+}
+
+// replace swaps in results read afresh, as from a shared directory.
+func (c *class) replace(students map[string][]Result) {
+	c.mu.Lock()
+	c.students = students
+	c.mu.Unlock()
+	c.notify()
+}
+
+// classRow is one student's line in the class table.
+type classRow struct {
+	Student string
+	Lessons int
+	Latest  Result // the most recent finished lesson
+	Weakest string // keys missed most in recent lessons, as "e×7 ;×4"
+}
+
+// rows summarises the class, one row per student in name order.
+func (c *class) rows() []classRow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var rows []classRow
+	for name, rs := range c.students {
+		if len(rs) == 0 {
+			continue
+		}
+		row := classRow{Student: name, Lessons: len(rs), Latest: rs[len(rs)-1]}
+		keys, counts := weakestKeys(rs[max(0, len(rs)-weakWindow):], weakKeys)
+		var weak []string
+		for i, k := range keys {
+			weak = append(weak, fmt.Sprintf("%s×%d", keyLabel(k), counts[i]))
+		}
+		row.Weakest = strings.Join(weak, " ")
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Student < rows[j].Student })
+	return rows
+}
+
+// weakestKeys returns the n keys missed most often in rs, worst first,
+// with their miss counts.
+func weakestKeys(rs []Result, n int) ([]string, []int) {
+	total := map[string]int{}
+	for _, r := range rs {
+		for k, m := range r.Misses {
+			total[k] += m
+		}
+	}
+	keys := make([]string, 0, len(total))
+	for k := range total {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if total[keys[i]] != total[keys[j]] {
+			return total[keys[i]] > total[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	keys = keys[:min(n, len(keys))]
+	counts := make([]int, len(keys))
+	for i, k := range keys {
+		counts[i] = total[k]
+	}
+	return keys, counts
+}
+
+// keyLabel makes a key readable in a table: space is shown as ␣.
+func keyLabel(k string) string {
+	if k == " " {
+		return "␣"
+	}
+	return k
+}
+
+// ── Collecting ───────────────────────────────────────────────────────
+
+// listenClass accepts student reports on addr, one JSON object per
+// line, until the listener is closed.
+func listenClass(addr string, c *class) (net.Listener, error) {
+	ln, err := net.Listen("tcp", withDefaultPort(addr, classroomDefaultPort))
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				dec := json.NewDecoder(bufio.NewReader(conn))
+				for {
+					var rep classReport
+					if dec.Decode(&rep) != nil {
+						return
+					}
+					if name := strings.TrimSpace(rep.Student); name != "" {
+						c.add(truncate(name, 40), rep.Result)
+					}
+				}
+			}()
+		}
+	}()
+	return ln, nil
+}
+
+// loadClassDir reads a shared directory of history files. A file named
+// history.jsonl belongs to the student its directory is named after,
+// as in a profiles directory; any other name.jsonl to name.
+func loadClassDir(dir string) (map[string][]Result, error) {
+	students := map[string][]Result{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".jsonl" {
+			return err
+		}
+		name := strings.TrimSuffix(d.Name(), ".jsonl")
+		if d.Name() == "history.jsonl" {
+			name = filepath.Base(filepath.Dir(path))
+		}
+		rs, err := newHistory(path).load()
+		if err != nil {
+			return err
+		}
+		students[name] = append(students[name], rs...)
+		return nil
+	})
+	for _, rs := range students {
+		sort.SliceStable(rs, func(i, j int) bool { return rs[i].Time.Before(rs[j].Time) })
+	}
+	return students, err
+}
+
+// ── Reports ──────────────────────────────────────────────────────────
+
+var classCSVHeader = []string{"student", "lessons", "latest_lesson", "time", "cpm", "wpm", "accuracy", "grade", "weakest_keys"}
+
+func writeClassCSV(w io.Writer, rows []classRow) error {
+	cw := csv.NewWriter(w)
+	cw.Write(classCSVHeader)
+	ff := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	for _, row := range rows {
+		r := row.Latest
+		cw.Write([]string{
+			row.Student, strconv.Itoa(row.Lessons), r.Lesson, r.Time.Format(time.RFC3339),
+			ff(r.CPM), ff(r.WPM), ff(r.Accuracy), r.Grade, row.Weakest,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var classHTML = template.Must(template.New("class").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Class report {{.Date}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #999; padding: 0.3em 0.8em; text-align: left; }
+th { background: #ddd; }
+td.n { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Class report</h1>
+<p>{{len .Rows}} students, {{.Date}}</p>
+<table>
+<tr><th>Student</th><th>Lessons</th><th>Latest lesson</th><th>When</th><th>CPM</th><th>WPM</th><th>Accuracy</th><th>Grade</th><th>Weakest keys</th></tr>
+{{range .Rows}}<tr><td>{{.Student}}</td><td class="n">{{.Lessons}}</td><td>{{.Latest.Lesson}}</td><td>{{.Latest.Time.Format "2006-01-02 15:04"}}</td><td class="n">{{printf "%.0f" .Latest.CPM}}</td><td class="n">{{printf "%.0f" .Latest.WPM}}</td><td class="n">{{printf "%.1f%%" .Latest.Accuracy}}</td><td>{{.Latest.Grade}}</td><td>{{.Weakest}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+func writeClassHTML(w io.Writer, rows []classRow) error {
+	return classHTML.Execute(w, struct {
+		Date string
+		Rows []classRow
+	}{now().Format("2006-01-02 15:04"), rows})
+}
+
+// writeClassReport writes rows to path in the given format.
+func writeClassReport(path, format string, rows []classRow) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if format == "html" {
+		err = writeClassHTML(f, rows)
+	} else {
+		err = writeClassCSV(f, rows)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// ── Screen ───────────────────────────────────────────────────────────
+
+// classShown is how many students fit in the table at once.
+const classShown = 15
+
+// truncate cuts s to at most w columns, marking a cut with ….
+func truncate(s string, w int) string {
+	if vLen(s) <= w {
+		return s
+	}
+	var b strings.Builder
+	for _, g := range graphemes(s) {
+		if vLen(b.String())+clusterWidth(g) > w-1 {
+			break
+		}
+		b.WriteString(g)
+	}
+	return b.String() + "…"
+}
+
+// cell pads or cuts s to exactly w columns.
+func cell(s string, w int) string {
+	s = truncate(s, w)
+	return s + strings.Repeat(" ", w-vLen(s))
+}
+
+func (u *ui) renderClassroom(rows []classRow, info, msg string, top int, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"TT — Classroom"+RST+TTBg) + "\n")
+	b.WriteString(hRow(TTDim+info+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTTitle+cell("Student", 14)+" "+cell("Done", 4)+" "+cell("Latest lesson", 24)+" "+
+		cell("  CPM", 5)+" "+cell("   Acc", 6)+" "+"Weakest keys"+RST+TTBg) + "\n")
+	for i := top; i < len(rows) && i < top+classShown; i++ {
+		row := rows[i]
+		accCol := FgGrn
+		if row.Latest.Accuracy < 90 {
+			accCol = FgRed
+		}
+		b.WriteString(hRow(fmt.Sprintf("%s%s %4d %s %5.0f %s%5.1f%%%s %s%s%s",
+			FgCyn+BOLD, cell(row.Student, 14)+RST+TTBg, row.Lessons, cell(row.Latest.Lesson, 24),
+			row.Latest.CPM, accCol, row.Latest.Accuracy, RST+TTBg,
+			FgYlw, truncate(row.Weakest, 18), RST+TTBg)) + "\n")
+	}
+	if len(rows) == 0 {
+		b.WriteString(hRow(TTDim+"No results yet — they appear as students finish lessons."+RST+TTBg) + "\n")
+	}
+	b.WriteString(hMid() + "\n")
+	if msg != "" {
+		b.WriteString(hRow(msg) + "\n")
+	}
+	b.WriteString(hRow(TTDim+"↑/↓ Scroll │ C Export CSV │ H Export HTML │ Q Quit"+RST+TTBg) + "\n")
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+// runClassroom shows the class table, redrawing it as results come in,
+// until the teacher quits.
+func (u *ui) runClassroom(keys <-chan keyEvent, c *class, info string) {
+	rows := c.rows()
+	top := 0
+	msg := ""
+	u.renderClassroom(rows, info, msg, top, true)
+	for {
+		select {
+		case <-c.changed:
+			rows = c.rows()
+			u.renderClassroom(rows, info, msg, top, false)
+		case k := <-keys:
+			switch {
+			case k.kind == evCtrlC || k.kind == evEscape || k.kind == evChar && (k.ch == 'q' || k.ch == 'Q'):
+				return
+			case k.kind == evUp && top > 0:
+				top--
+			case k.kind == evDown && top+classShown < len(rows):
+				top++
+			case k.kind == evChar && strings.ContainsRune("cChH", k.ch):
+				format := "csv"
+				if k.ch == 'h' || k.ch == 'H' {
+					format = "html"
+				}
+				path := "class-report." + format
+				if err := writeClassReport(path, format, rows); err != nil {
+					msg = FgRed + err.Error() + RST + TTBg
+				} else {
+					msg = FgGrn + "Wrote " + path + RST + TTBg
+				}
+			default:
+				continue
+			}
+			u.renderClassroom(rows, info, msg, top, false)
+		}
+	}
+}
+
+// classroom runs `tt classroom`; args are the words after "classroom".
+func classroom(args []string) error {
+	flags := flag.NewFlagSet("classroom", flag.ContinueOnError)
+	dir := flags.String("dir", "", "read history files from this shared directory instead of listening")
+	format := flags.String("format", "", "write a csv or html report of -dir and exit")
+	out := flags.String("o", "", "write the report to this file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	// flags may follow the address too
+	addr := flags.Arg(0)
+	if addr != "" {
+		if err := flags.Parse(flags.Args()[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() > 0 {
+			return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+		}
+	}
+	if *format != "" && *format != "csv" && *format != "html" {
+		return fmt.Errorf("unknown format %q (choose csv or html)", *format)
+	}
+	c := newClass()
+	var info string
+	if *dir != "" {
+		students, err := loadClassDir(*dir)
+		if err != nil {
+			return err
+		}
+		c.replace(students)
+		info = "Reading " + *dir
+	}
+
+	if *format != "" {
+		if *dir == "" {
+			return fmt.Errorf("-format needs -dir")
+		}
+		if *out != "" {
+			return writeClassReport(*out, *format, c.rows())
+		}
+		if *format == "html" {
+			return writeClassHTML(os.Stdout, c.rows())
+		}
+		return writeClassCSV(os.Stdout, c.rows())
+	}
+
+	if *dir != "" {
+		// pick up lessons finished since, every few seconds
+		go func() {
+			for range time.Tick(3 * time.Second) {
+				if students, err := loadClassDir(*dir); err == nil {
+					c.replace(students)
+				}
+			}
+		}()
+	} else {
+		if addr == "" {
+			addr = ":" + classroomDefaultPort
+		}
+		ln, err := listenClass(addr, c)
+		if err != nil {
+			return err
+		}
+		defer ln.Close()
+		_, port, _ := net.SplitHostPort(ln.Addr().String())
+		info = fmt.Sprintf("Listening — students run: tt -classroom %s:%s", localIP(), port)
+	}
+	return withConsole(func(u *ui, keys <-chan keyEvent) error {
+		defer u.goodbye()
+		u.runClassroom(keys, c, info)
+		return nil
+	})
+}
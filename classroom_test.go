@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionMisses(t *testing.T) {
+	s := newSession(&Lesson{Name: "l", Lines: []string{"ab ab"}})
+	for _, r := range "xbc" {
+		s.addRune(r)
+	}
+	s.backspace()
+	s.addRune(' ') // corrected mistakes still count
+	if s.misses["a"] != 1 || s.misses[" "] != 1 || len(s.misses) != 2 {
+		t.Errorf("misses = %v", s.misses)
+	}
+}
+
+func TestClassRows(t *testing.T) {
+	c := newClass()
+	c.add("zoe", Result{Lesson: "Lesson 1", CPM: 90, Misses: map[string]int{"e": 2, " ": 5}})
+	c.add("zoe", Result{Lesson: "Lesson 2", CPM: 120, Misses: map[string]int{"e": 4, ";": 1, "q": 1}})
+	c.add("al", Result{Lesson: "Lesson 1", CPM: 60})
+	rows := c.rows()
+	if len(rows) != 2 || rows[0].Student != "al" || rows[1].Student != "zoe" {
+		t.Fatalf("rows = %+v", rows)
+	}
+	zoe := rows[1]
+	if zoe.Lessons != 2 || zoe.Latest.Lesson != "Lesson 2" || zoe.Weakest != "e×6 ␣×5 ;×1" {
+		t.Errorf("zoe = %+v", zoe)
+	}
+	if rows[0].Weakest != "" {
+		t.Errorf("al weakest = %q", rows[0].Weakest)
+	}
+}
+
+func TestClassroomLAN(t *testing.T) {
+	c := newClass()
+	ln, err := listenClass("127.0.0.1:0", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	if err := sendReport(ln.Addr().String(), classReport{Student: "ann", Result: Result{Lesson: "Lesson 3", CPM: 150}}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("report not received")
+	}
+	if rows := c.rows(); len(rows) != 1 || rows[0].Student != "ann" || rows[0].Latest.CPM != 150 {
+		t.Errorf("rows = %+v", rows)
+	}
+}
+
+func TestLoadClassDir(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "ann"), 0o755)
+	newHistory(filepath.Join(dir, "ann", "history.jsonl")).add(Result{Lesson: "Lesson 1"})
+	newHistory(filepath.Join(dir, "bob.jsonl")).add(Result{Lesson: "Lesson 2", Time: time.Unix(2, 0)})
+	newHistory(filepath.Join(dir, "bob.jsonl")).add(Result{Lesson: "Lesson 1", Time: time.Unix(1, 0)})
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o644)
+	students, err := loadClassDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(students) != 2 || len(students["ann"]) != 1 || students["bob"][1].Lesson != "Lesson 2" {
+		t.Errorf("students = %+v", students)
+	}
+}
+
+func TestClassReports(t *testing.T) {
+	rows := []classRow{{Student: "<b>eve</b>", Lessons: 1, Latest: Result{Lesson: "Lesson 1", CPM: 100, Accuracy: 95}, Weakest: "e×2"}}
+	var b bytes.Buffer
+	if err := writeClassHTML(&b, rows); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "<b>eve") || !strings.Contains(b.String(), "&lt;b&gt;eve") {
+		t.Error("student name not escaped in HTML")
+	}
+	b.Reset()
+	writeClassCSV(&b, rows)
+	if lines := strings.Split(strings.TrimSpace(b.String()), "\n"); len(lines) != 2 || !strings.HasSuffix(lines[1], ",e×2") {
+		t.Errorf("csv = %q", b.String())
+	}
+}
+
+func TestClassroomFlagsAfterAddr(t *testing.T) {
+	dir := t.TempDir()
+	newHistory(filepath.Join(dir, "ann.jsonl")).add(Result{Lesson: "Lesson 1"})
+	out := filepath.Join(t.TempDir(), "report.csv")
+	if err := classroom([]string{":0", "-dir", dir, "-format", "csv", "-o", out}); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(out); err != nil || !strings.Contains(string(data), "ann") {
+		t.Fatalf("report %q, %v", data, err)
+	}
+	if err := classroom([]string{":0", "-format", "csv", "extra"}); err == nil || !strings.Contains(err.Error(), "extra") {
+		t.Fatalf("a second address gave %v", err)
+	}
+}
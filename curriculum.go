@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Curriculum — lesson targets, mastery and unlocking
+// ════════════════════════════════════════════════════════════════════
+
+// starSteps are the speeds, as multiples of the target, that earn a
+// mastered lesson its stars.
+var starSteps = []float64{1.2, 1.4, 1.6}
+
+// Target is the pass mark of one lesson, as kept in a targets file.
+type Target struct {
+	CPM      float64 `json:"cpm"`
+	Accuracy float64 `json:"accuracy"`
+	Grading  string  `json:"grading,omitempty"`
+}
+
+// meets reports whether r reaches the targets of l.
+func (l *Lesson) meets(r Result) bool {
+	return r.CPM >= l.TargetCPM && r.Accuracy >= l.TargetAcc
+}
+
+// mastery returns whether l is mastered in rs and its stars: one for
+// each of starSteps the fastest passing run reaches.
+func (l *Lesson) mastery(rs []Result) (mastered bool, stars int) {
+	best := -1.0
+	for _, r := range rs {
+		if r.Lesson == l.Name && l.meets(r) {
+			best = max(best, r.CPM)
+		}
+	}
+	if best < 0 {
+		return false, 0
+	}
+	for _, step := range starSteps {
+		if l.TargetCPM > 0 && best >= step*l.TargetCPM {
+			stars++
+		}
+	}
+	return true, stars
+}
+
+// unlocked reports whether lesson i may be started: outside curriculum
+// mode always, inside it once the lesson before is mastered.
+func (u *ui) unlocked(i int) bool {
+	if !u.settings.Curriculum || i == 0 || i >= len(lessons) {
+		return true
+	}
+	ok, _ := lessons[i-1].mastery(u.results)
+	return ok
+}
+
+// lessonBadge is the mark after a lesson's name in curriculum mode: a
+// check and stars once mastered, ⊘ while locked.
+func (u *ui) lessonBadge(i int) string {
+	if !u.settings.Curriculum {
+		return ""
+	}
+	if !u.unlocked(i) {
+		return TTDim + "⊘" + RST + TTBg
+	}
+	ok, stars := lessons[i].mastery(u.results)
+	if !ok {
+		return ""
+	}
+	return FgGrn + BOLD + "✓" + FgYlw + strings.Repeat("★", stars) + RST + TTBg
+}
+
+// badgeW is the room kept for a lesson badge.
+const badgeW = 4
+
+// curriculumCell is menuCell for lesson i with its badge at the right
+// edge of the column, shortening the name when it does not fit.
+func (u *ui) curriculumCell(i, sel int) string {
+	name := lessons[i].Name
+	badge := u.lessonBadge(i)
+	if !u.settings.Curriculum {
+		return menuCell(i, sel, name)
+	}
+	room := menuColW - 6 - badgeW - 1 // marker, number, badge and a gap
+	if rs := []rune(name); len(rs) > room {
+		name = string(rs[:room-1]) + "…"
+	}
+	if !u.unlocked(i) && i != sel {
+		name = TTDim + name
+	}
+	cell := menuCell(i, sel, name)
+	cell = strings.TrimRight(cell, " ")
+	if pad := menuColW - badgeW - vLen(cell); pad > 0 {
+		cell += strings.Repeat(" ", pad)
+	}
+	cell += badge
+	if pad := menuColW - vLen(cell); pad > 0 {
+		cell += strings.Repeat(" ", pad)
+	}
+	return cell
+}
+
+// targetLine tells on the score report whether the run just recorded
+// met the targets of l, and what it unlocked.
+func (u *ui) targetLine(l *Lesson) string {
+	line := fmt.Sprintf("Target:   %s%.0f CPM, %.0f%% accuracy%s", TTFg+BOLD, l.TargetCPM, l.TargetAcc, RST+TTBg)
+	if u.last == nil || !l.meets(*u.last) {
+		return line + "  " + TTDim + "not yet mastered" + RST + TTBg
+	}
+	line += "  " + FgGrn + BOLD + "✓ Mastered" + RST + TTBg
+	// the run just recorded is the last result
+	if was, _ := l.mastery(u.results[:len(u.results)-1]); was || !u.settings.Curriculum {
+		return line
+	}
+	for i := range lessons[:len(lessons)-1] {
+		if &lessons[i] == l {
+			line += fmt.Sprintf("  %sLesson %d unlocked!%s", FgYlw+BOLD, i+2, RST+TTBg)
+		}
+	}
+	return line
+}
+
+func (st *Settings) toggleCurriculum() { st.Curriculum = !st.Curriculum }
+
+func (st *Settings) curriculumName() string {
+	if st.Curriculum {
+		return "On"
+	}
+	return "Off"
+}
+
+// lessonTargets returns the targets of the built-in lessons by name.
+func lessonTargets() map[string]Target {
+	ts := map[string]Target{}
+	for _, l := range lessons {
+		ts[l.Name] = Target{CPM: l.TargetCPM, Accuracy: l.TargetAcc, Grading: l.Grading}
+	}
+	return ts
+}
+
+// loadTargets overrides lesson targets from a JSON file mapping lesson
+// names to targets, as written by `tt targets`, so a teacher can set
+// the pass marks for a class.
+func loadTargets(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var ts map[string]Target
+	if err := json.Unmarshal(data, &ts); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for name, t := range ts {
+		if t.Grading != "" {
+			if _, err := gradingByName(t.Grading); err != nil {
+				return fmt.Errorf("%s: %q: %w", path, name, err)
+			}
+		}
+		found := false
+		for i := range lessons {
+			if lessons[i].Name == name {
+				lessons[i].TargetCPM, lessons[i].TargetAcc, lessons[i].Grading = t.CPM, t.Accuracy, t.Grading
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: no lesson named %q", path, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMastery(t *testing.T) {
+	l := &Lesson{Name: "l", TargetCPM: 100, TargetAcc: 90}
+	cases := []struct {
+		rs       []Result
+		mastered bool
+		stars    int
+	}{
+		{nil, false, 0},
+		{[]Result{{Lesson: "l", CPM: 99, Accuracy: 99}}, false, 0},
+		{[]Result{{Lesson: "l", CPM: 300, Accuracy: 89}}, false, 0},
+		{[]Result{{Lesson: "other", CPM: 300, Accuracy: 99}}, false, 0},
+		{[]Result{{Lesson: "l", CPM: 100, Accuracy: 90}}, true, 0},
+		{[]Result{{Lesson: "l", CPM: 145, Accuracy: 95}, {Lesson: "l", CPM: 500, Accuracy: 50}}, true, 2},
+		{[]Result{{Lesson: "l", CPM: 160, Accuracy: 95}}, true, 3},
+	}
+	for i, c := range cases {
+		if ok, stars := l.mastery(c.rs); ok != c.mastered || stars != c.stars {
+			t.Errorf("case %d: mastery = %v, %d; want %v, %d", i, ok, stars, c.mastered, c.stars)
+		}
+	}
+}
+
+func TestLoadTargets(t *testing.T) {
+	saved := append([]Lesson(nil), lessons...)
+	t.Cleanup(func() { copy(lessons, saved) })
+
+	path := filepath.Join(t.TempDir(), "targets.json")
+	os.WriteFile(path, []byte(`{"`+lessons[2].Name+`": {"cpm": 42, "accuracy": 97.5}}`), 0o644)
+	if err := loadTargets(path); err != nil {
+		t.Fatal(err)
+	}
+	if l := lessons[2]; l.TargetCPM != 42 || l.TargetAcc != 97.5 {
+		t.Errorf("targets = %v, %v", l.TargetCPM, l.TargetAcc)
+	}
+	os.WriteFile(path, []byte(`{"No Such Lesson": {"cpm": 1}}`), 0o644)
+	if err := loadTargets(path); err == nil {
+		t.Error("unknown lesson accepted")
+	}
+}
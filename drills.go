@@ -0,0 +1,556 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Shortcut drills — editor and shell commands, with spaced repetition
+// ════════════════════════════════════════════════════════════════════
+
+// drillCard asks for an action; Keys are the answers that count, in
+// Vim's key notation: <C-x> and <M-x> chords, <CR>, <Esc>, <Tab>, <BS>,
+// <Space>, <Up>/<Down>/<Left>/<Right> and <lt>; the rest are typed.
+type drillCard struct {
+	Prompt string
+	Keys   []string
+}
+
+type drillDeck struct {
+	Name  string
+	Cards []drillCard
+}
+
+// drillDecks are the decks on the drill screen. No answer uses Ctrl-C,
+// which quits, or the chords terminals send as other keys (C-h, C-i,
+// C-j, C-m, C-[).
+var drillDecks = []drillDeck{
+	{"Vim", []drillCard{
+		{"Move to the start of the next word", []string{"w"}},
+		{"Move to the end of the line", []string{"$"}},
+		{"Move to the first non-blank character of the line", []string{"^"}},
+		{"Go to the first line of the file", []string{"gg"}},
+		{"Go to the last line of the file", []string{"G"}},
+		{"Jump to the matching bracket", []string{"%"}},
+		{"Scroll half a page down", []string{"<C-d>"}},
+		{"Delete the current line", []string{"dd"}},
+		{"Delete to the end of the line", []string{"D", "d$"}},
+		{"Delete to the start of the next word", []string{"dw"}},
+		{"Change the word under the cursor", []string{"ciw"}},
+		{"Replace the character under the cursor with x", []string{"rx"}},
+		{"Copy (yank) the current line", []string{"yy", "Y"}},
+		{"Paste after the cursor", []string{"p"}},
+		{"Open a new line below and start inserting", []string{"o"}},
+		{"Join the next line onto this one", []string{"J"}},
+		{"Indent the current line", []string{">>"}},
+		{"Undo", []string{"u"}},
+		{"Redo", []string{"<C-r>"}},
+		{"Repeat the last change", []string{"."}},
+		{"Search forward for foo", []string{"/foo<CR>"}},
+		{"Jump to the next search match", []string{"n"}},
+		{"Save and quit", []string{":wq<CR>", ":x<CR>", "ZZ"}},
+		{"Quit without saving", []string{":q!<CR>", "ZQ"}},
+	}},
+	{"Emacs", []drillCard{
+		{"Save the buffer", []string{"<C-x><C-s>"}},
+		{"Open a file", []string{"<C-x><C-f>"}},
+		{"Switch to another buffer", []string{"<C-x>b"}},
+		{"Cancel the current command", []string{"<C-g>"}},
+		{"Move to the beginning of the line", []string{"<C-a>"}},
+		{"Move to the end of the line", []string{"<C-e>"}},
+		{"Move to the next line", []string{"<C-n>"}},
+		{"Move to the previous line", []string{"<C-p>"}},
+		{"Move forward one word", []string{"<M-f>"}},
+		{"Move back one word", []string{"<M-b>"}},
+		{"Go to the beginning of the buffer", []string{"<M-<>"}},
+		{"Go to the end of the buffer", []string{"<M->>"}},
+		{"Kill to the end of the line", []string{"<C-k>"}},
+		{"Yank (paste) the last kill", []string{"<C-y>"}},
+		{"Replace a yank with an earlier kill", []string{"<M-y>"}},
+		{"Set the mark", []string{"<C-Space>"}},
+		{"Kill the region", []string{"<C-w>"}},
+		{"Copy the region", []string{"<M-w>"}},
+		{"Undo", []string{"<C-_>", "<C-x>u"}},
+		{"Search forward incrementally", []string{"<C-s>"}},
+		{"Search backward incrementally", []string{"<C-r>"}},
+		{"Run a command by name", []string{"<M-x>"}},
+		{"Split the window in two, one above the other", []string{"<C-x>2"}},
+		{"Switch to the other window", []string{"<C-x>o"}},
+		{"Kill the current buffer", []string{"<C-x>k"}},
+	}},
+	{"Shell", []drillCard{
+		{"Move to the start of the command line", []string{"<C-a>"}},
+		{"Move to the end of the command line", []string{"<C-e>"}},
+		{"Move back one word", []string{"<M-b>"}},
+		{"Move forward one word", []string{"<M-f>"}},
+		{"Delete the word before the cursor", []string{"<C-w>"}},
+		{"Delete from the cursor to the start of the line", []string{"<C-u>"}},
+		{"Delete from the cursor to the end of the line", []string{"<C-k>"}},
+		{"Search the history backwards", []string{"<C-r>"}},
+		{"Insert the last argument of the previous command", []string{"<M-.>", "<M-_>"}},
+		{"Complete the file name", []string{"<Tab>"}},
+		{"Clear the screen", []string{"<C-l>"}},
+		{"Suspend the running program", []string{"<C-z>"}},
+		{"Bring the stopped program back to the foreground", []string{"fg<CR>"}},
+		{"Send end-of-file, logging out of an empty shell", []string{"<C-d>"}},
+		{"Run the previous command again", []string{"!!<CR>"}},
+		{"Go to your home directory", []string{"cd<CR>", "cd ~<CR>"}},
+		{"Go back to the previous directory", []string{"cd -<CR>"}},
+		{"Print the current directory", []string{"pwd<CR>"}},
+		{"List every file, hidden ones too, in long format", []string{"ls -la<CR>", "ls -al<CR>"}},
+		{"Follow access.log as it grows", []string{"tail -f access.log<CR>"}},
+	}},
+}
+
+// ── Keys ─────────────────────────────────────────────────────────────
+
+// drillKey is one key press: a typed rune, a Ctrl or Alt chord, or a
+// named key such as evEnter.
+type drillKey struct {
+	kind int
+	ch   rune
+}
+
+var namedKeys = map[string]drillKey{
+	"CR": {kind: evEnter}, "Esc": {kind: evEscape}, "Tab": {kind: evTab}, "BS": {kind: evBackspace},
+	"Up": {kind: evUp}, "Down": {kind: evDown}, "Left": {kind: evLeft}, "Right": {kind: evRight},
+	"Space": {evChar, ' '}, "lt": {evChar, '<'},
+}
+
+// parseKeys reads key notation. A '<' that opens no valid name is
+// typed as it is.
+func parseKeys(s string) []drillKey {
+	var out []drillKey
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		if rs[i] == '<' {
+			// the shortest valid name wins, so <M-<> and <M->> work
+			found := false
+			for j := i + 2; j < len(rs) && j < i+10 && !found; j++ {
+				if rs[j] != '>' {
+					continue
+				}
+				if k, ok := parseKeyName(string(rs[i+1 : j])); ok {
+					out = append(out, k)
+					i, found = j, true
+				}
+			}
+			if found {
+				continue
+			}
+		}
+		out = append(out, drillKey{evChar, rs[i]})
+	}
+	return out
+}
+
+func parseKeyName(name string) (drillKey, bool) {
+	kind := 0
+	switch {
+	case strings.HasPrefix(name, "C-"):
+		kind = evCtrl
+	case strings.HasPrefix(name, "M-"), strings.HasPrefix(name, "A-"):
+		kind = evAlt
+	default:
+		k, ok := namedKeys[name]
+		return k, ok
+	}
+	key := []rune(name[2:])
+	switch {
+	case len(key) == 1:
+		return drillKey{kind, key[0]}, true
+	case kind == evCtrl && string(key) == "Space":
+		return drillKey{kind, ' '}, true
+	}
+	return drillKey{}, false
+}
+
+// String writes k in key notation.
+func (k drillKey) String() string {
+	switch k.kind {
+	case evChar:
+		if k.ch == '<' {
+			return "<lt>"
+		}
+		return string(k.ch)
+	case evCtrl:
+		if k.ch == ' ' {
+			return "<C-Space>"
+		}
+		return "<C-" + string(k.ch) + ">"
+	case evAlt:
+		return "<M-" + string(k.ch) + ">"
+	}
+	for name, n := range namedKeys {
+		if n == k && n.kind != evChar {
+			return "<" + name + ">"
+		}
+	}
+	return "?"
+}
+
+func keysString(ks []drillKey) string {
+	var b strings.Builder
+	for _, k := range ks {
+		b.WriteString(k.String())
+	}
+	return b.String()
+}
+
+// pressed is the keys of one key event; a read can bring several
+// typed runes at once.
+func pressed(k keyEvent) []drillKey {
+	switch k.kind {
+	case evNone, evCtrlC:
+		return nil
+	case evChar:
+		var ks []drillKey
+		for _, r := range k.text {
+			ks = append(ks, drillKey{evChar, r})
+		}
+		return ks
+	}
+	return []drillKey{{k.kind, k.ch}}
+}
+
+// ── Spaced repetition ────────────────────────────────────────────────
+
+// cardState is how well a card is known. Cards move up a Leitner box
+// each time they are answered right and fast, and are asked again
+// after the box's interval; a miss puts them back in box 0.
+type cardState struct {
+	Box    int       `json:"box"`
+	Due    time.Time `json:"due"`
+	Seen   int       `json:"seen"`
+	Missed int       `json:"missed"`
+	LastMS int64     `json:"last_ms"` // response time of the last answer
+	BestMS int64     `json:"best_ms"` // fastest right answer
+}
+
+// boxDays is how long a card in each box rests before it is due.
+var boxDays = []int{0, 1, 2, 4, 8, 16, 32}
+
+const (
+	drillFast  = 3 * time.Second // right answers slower than this stay in their box
+	drillRound = 12              // cards per round
+	drillNew   = 5               // cards never seen before, per round
+	drillAgain = 3               // a missed card comes back after this many others
+)
+
+func cardKey(d *drillDeck, c *drillCard) string { return d.Name + "/" + c.Prompt }
+
+// cardStates are the drill progress of the active profile, or of this
+// session when there is none.
+func (u *ui) cardStates() map[string]*cardState {
+	if u.profile != nil {
+		if u.profile.Cards == nil {
+			u.profile.Cards = map[string]*cardState{}
+		}
+		return u.profile.Cards
+	}
+	if u.cards == nil {
+		u.cards = map[string]*cardState{}
+	}
+	return u.cards
+}
+
+// answer files a response to the card with key: right or not, and how
+// long it took.
+func (u *ui) answer(key string, right bool, took time.Duration) {
+	cs := u.cardStates()[key]
+	if cs == nil {
+		cs = &cardState{}
+		u.cardStates()[key] = cs
+	}
+	cs.Seen++
+	cs.LastMS = took.Milliseconds()
+	switch {
+	case !right:
+		cs.Missed++
+		cs.Box = 0
+	case cs.BestMS == 0 || cs.LastMS < cs.BestMS:
+		cs.BestMS = cs.LastMS
+		fallthrough
+	default:
+		if took <= drillFast {
+			cs.Box = min(cs.Box+1, len(boxDays)-1)
+		} else {
+			cs.Box = max(cs.Box, 1)
+		}
+	}
+	if right {
+		cs.Due = now().AddDate(0, 0, boxDays[cs.Box])
+	} else {
+		cs.Due = now()
+	}
+}
+
+// deckCounts are the cards of d that are due, never seen, and resting
+// in box 3 or above.
+func (u *ui) deckCounts(d *drillDeck) (due, fresh, learnt int) {
+	states := u.cardStates()
+	for i := range d.Cards {
+		cs := states[cardKey(d, &d.Cards[i])]
+		switch {
+		case cs == nil:
+			fresh++
+		case !cs.Due.After(now()):
+			due++
+		case cs.Box >= 3:
+			learnt++
+		}
+	}
+	return
+}
+
+// round picks the cards of the next round: the due ones, longest
+// overdue first, then a few new ones. With none of either it reviews
+// the cards due soonest, and says so.
+func (u *ui) round(d *drillDeck) (cards []int, early bool) {
+	states := u.cardStates()
+	var due, fresh, rest []int
+	for i := range d.Cards {
+		cs := states[cardKey(d, &d.Cards[i])]
+		switch {
+		case cs == nil:
+			fresh = append(fresh, i)
+		case !cs.Due.After(now()):
+			due = append(due, i)
+		default:
+			rest = append(rest, i)
+		}
+	}
+	byDue := func(is []int) {
+		sort.SliceStable(is, func(a, b int) bool {
+			return states[cardKey(d, &d.Cards[is[a]])].Due.Before(states[cardKey(d, &d.Cards[is[b]])].Due)
+		})
+	}
+	byDue(due)
+	cards = append(due, fresh[:min(len(fresh), drillNew)]...)
+	if len(cards) == 0 {
+		byDue(rest)
+		cards, early = rest, true
+	}
+	return cards[:min(len(cards), drillRound)], early
+}
+
+// ── Drill screen ─────────────────────────────────────────────────────
+
+// drillRun is a round of a deck in progress.
+type drillRun struct {
+	deck  *drillDeck
+	queue []int      // cards still to ask, the current one first
+	early bool       // nothing was due; reviewing ahead of time
+	got   []drillKey // keys pressed for the current card
+	shown time.Time  // when the current card was asked
+	note  string     // how the last answer went
+
+	asked, right int
+	took         map[int]time.Duration // response time per card, last answer
+}
+
+// press takes a key for the current card and reports whether the card
+// is answered, and if so, rightly.
+func (dr *drillRun) press(k drillKey) (done, right bool) {
+	dr.got = append(dr.got, k)
+	got := keysString(dr.got)
+	prefix := false
+	for _, ans := range dr.deck.Cards[dr.queue[0]].Keys {
+		want := keysString(parseKeys(ans))
+		if got == want {
+			return true, true
+		}
+		prefix = prefix || strings.HasPrefix(want, got) && len(dr.got) < len(parseKeys(ans))
+	}
+	return !prefix, false
+}
+
+// nextCard files the answer to the current card and moves on; a missed
+// card is asked again a few cards later.
+func (u *ui) nextCard(dr *drillRun, right bool) {
+	i := dr.queue[0]
+	c := &dr.deck.Cards[i]
+	took := now().Sub(dr.shown)
+	u.answer(cardKey(dr.deck, c), right, took)
+	dr.asked++
+	dr.took[i] = took
+	dr.queue = dr.queue[1:]
+	if right {
+		dr.right++
+		dr.note = fmt.Sprintf("%s✓ %s%s  %.1fs", FgGrn+BOLD, keysString(dr.got), RST+TTBg, took.Seconds())
+	} else {
+		dr.note = fmt.Sprintf("%s✗ %s%s — %s: %s%s%s", FgRed+BOLD, keysString(dr.got), RST+TTBg,
+			truncate(c.Prompt, 30), FgYlw+BOLD, c.Keys[0], RST+TTBg)
+		at := min(drillAgain, len(dr.queue))
+		dr.queue = append(dr.queue[:at], append([]int{i}, dr.queue[at:]...)...)
+	}
+	dr.got = nil
+	dr.shown = now()
+}
+
+func (u *ui) renderDrills(sel int, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"TT — Shortcut Drills"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTTitle+"Select Deck:"+RST+TTBg) + "\n")
+	b.WriteString(hBlank() + "\n")
+	for i := range drillDecks {
+		d := &drillDecks[i]
+		due, fresh, learnt := u.deckCounts(d)
+		cell := menuCell(i, sel, fmt.Sprintf("%s (%d cards)", d.Name, len(d.Cards)))
+		cell += fmt.Sprintf("%s%2d due  %2d new  %2d learnt%s", TTDim, due, fresh, learnt, RST+TTBg)
+		b.WriteString(hRow(cell) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hRow(TTDim+"Each card names an action; press its keys, chords and all. Fast right"+RST+TTBg) + "\n")
+	b.WriteString(hRow(TTDim+"answers come back after longer and longer rests; misses come back soon."+RST+TTBg) + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTDim+"Up/Down Select │ Enter Start │ ESC Back │ Q Quit"+RST+TTBg) + "\n")
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+func (u *ui) renderDrill(dr *drillRun, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	title := dr.deck.Name + " Drill"
+	if dr.early {
+		title += TTDim + "  (nothing due — reviewing early)" + RST + TTBg + BOLD + TTTitle
+	}
+	b.WriteString(hCenter(BOLD+TTTitle+title+RST+TTBg) + "\n")
+	b.WriteString(hRow(fmt.Sprintf("Card %d │ %d left │ %s%d right%s", dr.asked+1, len(dr.queue), FgGrn, dr.right, RST+TTBg)) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hCenter(BOLD+TTFg+dr.deck.Cards[dr.queue[0]].Prompt+RST+TTBg) + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hCenter(FgCyn+BOLD+keysString(dr.got)+RST+TTBg+REV+" "+RST+TTBg) + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(dr.note) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTDim+"Press the keys │ ESC before any key = Back │ Ctrl-C=Quit"+RST+TTBg) + "\n")
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+func (u *ui) renderDrillDone(dr *drillRun) {
+	u.cls()
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+dr.deck.Name+" Drill — Round Complete"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	var total time.Duration
+	var order []int
+	for i, t := range dr.took {
+		total += t
+		order = append(order, i)
+	}
+	b.WriteString(hRow(fmt.Sprintf("Answers:  %s%d%s, %s%d right%s", TTFg+BOLD, dr.asked, RST+TTBg, FgGrn+BOLD, dr.right, RST+TTBg)) + "\n")
+	if len(order) > 0 {
+		b.WriteString(hRow(fmt.Sprintf("Average:  %s%.1fs%s per card", FgYlw+BOLD, total.Seconds()/float64(len(order)), RST+TTBg)) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hRow(TTTitle+"Slowest cards:"+RST+TTBg) + "\n")
+	sort.Slice(order, func(a, b int) bool {
+		if dr.took[order[a]] != dr.took[order[b]] {
+			return dr.took[order[a]] > dr.took[order[b]]
+		}
+		return order[a] < order[b]
+	})
+	for _, i := range order[:min(len(order), 5)] {
+		c := &dr.deck.Cards[i]
+		b.WriteString(hRow(fmt.Sprintf("  %5.1fs  %s  %s%s%s", dr.took[i].Seconds(), cell(c.Prompt, 50), FgYlw, c.Keys[0], RST+TTBg)) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	due, fresh, learnt := u.deckCounts(dr.deck)
+	b.WriteString(hRow(fmt.Sprintf("%sDeck: %d due │ %d new │ %d learnt%s", TTDim, due, fresh, learnt, RST+TTBg)) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTDim+"Enter=Next Round │ ESC=Decks │ Q=Quit"+RST+TTBg) + "\n")
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+// runDrills runs the drill screen until the user leaves. It returns
+// "menu" or "quit".
+func (u *ui) runDrills(keys <-chan keyEvent) string {
+	defer u.saveProfile()
+	sel := 0
+	var dr *drillRun
+	start := func() {
+		d := &drillDecks[sel]
+		cards, early := u.round(d)
+		dr = &drillRun{deck: d, queue: cards, early: early, shown: now(), took: map[int]time.Duration{}}
+		u.renderDrill(dr, true)
+	}
+	u.renderDrills(sel, true)
+	for {
+		k := <-keys
+		switch k.kind {
+		case evNone:
+			continue
+		case evCtrlC:
+			return "quit"
+		}
+		switch {
+		case dr == nil:
+			switch {
+			case k.kind == evUp:
+				sel = (sel + len(drillDecks) - 1) % len(drillDecks)
+				u.renderDrills(sel, false)
+			case k.kind == evDown:
+				sel = (sel + 1) % len(drillDecks)
+				u.renderDrills(sel, false)
+			case k.kind == evEnter:
+				start()
+			case k.kind == evEscape || k.kind == evChar && (k.ch == 'm' || k.ch == 'M'):
+				return "menu"
+			case k.kind == evChar && (k.ch == 'q' || k.ch == 'Q'):
+				return "quit"
+			}
+		case len(dr.queue) == 0:
+			switch {
+			case k.kind == evEnter:
+				start()
+			case k.kind == evEscape:
+				dr = nil
+				u.renderDrills(sel, true)
+			case k.kind == evChar && (k.ch == 'q' || k.ch == 'Q'):
+				return "quit"
+			}
+		case k.kind == evEscape && len(dr.got) == 0:
+			dr = nil
+			u.saveProfile()
+			u.renderDrills(sel, true)
+		default:
+			for _, p := range pressed(k) {
+				if done, right := dr.press(p); done {
+					u.nextCard(dr, right)
+					break
+				}
+			}
+			if len(dr.queue) == 0 {
+				u.saveProfile()
+				u.renderDrillDone(dr)
+			} else {
+				u.renderDrill(dr, false)
+			}
+		}
+	}
+}
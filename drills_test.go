@@ -0,0 +1,148 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseKeys(t *testing.T) {
+	for s, want := range map[string][]drillKey{
+		"dd":         {{evChar, 'd'}, {evChar, 'd'}},
+		"<C-x><C-s>": {{evCtrl, 'x'}, {evCtrl, 's'}},
+		"<M-<>":      {{evAlt, '<'}},
+		"<M->>":      {{evAlt, '>'}},
+		"<C-Space>":  {{evCtrl, ' '}},
+		"/foo<CR>":   {{evChar, '/'}, {evChar, 'f'}, {evChar, 'o'}, {evChar, 'o'}, {kind: evEnter}},
+		"a<b":        {{evChar, 'a'}, {evChar, '<'}, {evChar, 'b'}},
+		"<lt>":       {{evChar, '<'}},
+	} {
+		if got := parseKeys(s); !reflect.DeepEqual(got, want) {
+			t.Errorf("parseKeys(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestDrillDecks(t *testing.T) {
+	// keys the terminal turns into something else, or that quit
+	clash := map[drillKey]bool{
+		{evCtrl, 'c'}: true, {evCtrl, 'h'}: true, {evCtrl, 'i'}: true,
+		{evCtrl, 'j'}: true, {evCtrl, 'm'}: true, {evCtrl, '['}: true,
+	}
+	for _, d := range drillDecks {
+		seen := map[string]bool{}
+		for _, c := range d.Cards {
+			if seen[c.Prompt] {
+				t.Errorf("%s: %q asked twice", d.Name, c.Prompt)
+			}
+			seen[c.Prompt] = true
+			for _, ans := range c.Keys {
+				ks := parseKeys(ans)
+				if len(ks) == 0 || ks[0].kind == evEscape {
+					t.Errorf("%s: %q cannot be told from leaving the drill", d.Name, ans)
+				}
+				for _, k := range ks {
+					if clash[k] {
+						t.Errorf("%s: %q uses %v", d.Name, ans, k)
+					}
+				}
+				if again := keysString(parseKeys(keysString(ks))); again != keysString(ks) {
+					t.Errorf("%s: %q writes back as %q", d.Name, ans, again)
+				}
+			}
+		}
+	}
+}
+
+func TestReadKeyChords(t *testing.T) {
+	buf := make([]byte, 16)
+	for seq, want := range map[string]keyEvent{
+		"\x01":   {kind: evCtrl, ch: 'a'},
+		"\x12":   {kind: evCtrl, ch: 'r'},
+		"\x00":   {kind: evCtrl, ch: ' '},
+		"\x1f":   {kind: evCtrl, ch: '_'},
+		"\033f":  {kind: evAlt, ch: 'f'},
+		"\033<":  {kind: evAlt, ch: '<'},
+		"\x03":   {kind: evCtrlC},
+		"\x7f":   {kind: evBackspace},
+		"\033[A": {kind: evUp},
+	} {
+		if k, err := readKey(strings.NewReader(seq), buf); err != nil || k != want {
+			t.Errorf("%q read as %+v, %v; want %+v", seq, k, err, want)
+		}
+	}
+}
+
+func TestDrillPress(t *testing.T) {
+	d := &drillDeck{Name: "T", Cards: []drillCard{{"Delete to the end of the line", []string{"D", "d$"}}}}
+	dr := &drillRun{deck: d, queue: []int{0}}
+	if done, _ := dr.press(drillKey{evChar, 'd'}); done {
+		t.Fatal("d answered the card; want d$ still possible")
+	}
+	if done, right := dr.press(drillKey{evChar, '$'}); !done || !right {
+		t.Errorf("d$ gave done=%v right=%v", done, right)
+	}
+	dr.got = nil
+	if done, right := dr.press(drillKey{evChar, 'x'}); !done || right {
+		t.Errorf("x gave done=%v right=%v, want a miss", done, right)
+	}
+}
+
+func TestDrillScheduling(t *testing.T) {
+	day := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	now = func() time.Time { return day }
+	t.Cleanup(func() { now = time.Now })
+	u := &ui{}
+	d := &drillDecks[0]
+	key := cardKey(d, &d.Cards[0])
+
+	u.answer(key, true, time.Second)
+	if cs := u.cards[key]; cs.Box != 1 || !cs.Due.Equal(day.AddDate(0, 0, 1)) || cs.BestMS != 1000 {
+		t.Errorf("after a fast answer: %+v", cs)
+	}
+	u.answer(key, true, 5*time.Second)
+	if cs := u.cards[key]; cs.Box != 1 || cs.LastMS != 5000 || cs.BestMS != 1000 {
+		t.Errorf("after a slow answer: %+v", cs)
+	}
+	u.answer(key, false, time.Second)
+	if cs := u.cards[key]; cs.Box != 0 || cs.Missed != 1 || cs.Due.After(day) {
+		t.Errorf("after a miss: %+v", cs)
+	}
+
+	cards, early := u.round(d)
+	if early || cards[0] != 0 || len(cards) != 1+drillNew {
+		t.Errorf("round %v early=%v; want the missed card, then %d new", cards, early, drillNew)
+	}
+	for i := range d.Cards {
+		u.answer(cardKey(d, &d.Cards[i]), true, time.Second)
+	}
+	if cards, early = u.round(d); !early || len(cards) != drillRound {
+		t.Errorf("with nothing due: round %v early=%v", cards, early)
+	}
+}
+
+func TestGoldenDrills(t *testing.T) {
+	sc := newScenario(t)
+	// the drills follow the games in the right column
+	sc.press(keyEvent{kind: evRight})
+	for i := 0; i < 3; i++ {
+		sc.press(keyEvent{kind: evDown})
+	}
+	sc.press(keyEvent{kind: evEnter})
+	sc.snap("decks")
+	sc.press(keyEvent{kind: evEnter})
+	sc.snap("first card")
+	sc.press(sc.char('w'))
+	sc.snap("right")
+	sc.press(sc.char('x'))
+	sc.snap("missed")
+	for _, ans := range []string{"^", "gg", "G", "$"} {
+		sc.typeText(ans)
+	}
+	sc.snap("round complete")
+	sc.press(keyEvent{kind: evEscape})
+	sc.snap("decks after a round")
+	sc.press(keyEvent{kind: evEscape})
+	sc.finish()
+}
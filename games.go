@@ -0,0 +1,623 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Game framework — real-time typing games driven by runGame
+// ════════════════════════════════════════════════════════════════════
+
+// Game is a real-time typing game. runGame calls update on every tick
+// of the game's own ticker and forwards every key to handleKey.
+type Game interface {
+	ticker() Ticker
+	update(u *ui)
+	render(u *ui, first bool)
+	// handleKey returns "menu" or "quit" to leave the game, "" to stay.
+	// Keys come unremapped: -remap applies to what the player types at
+	// the game, not to control keys such as R, M and Q.
+	handleKey(u *ui, k keyEvent) string
+}
+
+// gameEntry registers a game in the main menu.
+type gameEntry struct {
+	Name string
+	New  func(seed int64) Game
+}
+
+// games lists every game shown in the menu, in menu order.
+var games = []gameEntry{
+	{"Space Invaders", newSpaceInvaders},
+	{"Word Stack", newWordStack},
+	{"Typing Racer", newRacer},
+}
+
+// scorer is a game whose final score counts as a high score.
+type scorer interface {
+	// finalScore returns the score and whether the game is over.
+	finalScore() (score int, over bool)
+}
+
+// runGame drives g with its own ticker until the game asks to leave.
+// A final score is kept as a high score of the game named name, unless
+// name is empty. Returns the action to take: "menu" or "quit".
+func (u *ui) runGame(keys <-chan keyEvent, name string, g Game) string {
+	g.render(u, true)
+
+	ticker := u.tick
+	if ticker == nil {
+		ticker = g.ticker()
+		defer ticker.Stop()
+	}
+
+	for {
+		select {
+		case <-ticker.C():
+			g.update(u)
+			u.noteScore(name, g)
+			g.render(u, false)
+		case k := <-keys:
+			if k.kind == evCtrlC {
+				return "quit"
+			}
+			act := g.handleKey(u, k)
+			u.noteScore(name, g)
+			if act != "" {
+				return act
+			}
+		}
+	}
+}
+
+// lessonWords collects the plain lowercase words used by the lessons,
+// in order of first appearance, as a word pool for the games.
+func lessonWords() []string {
+	seen := map[string]bool{}
+	var words []string
+	for _, l := range lessons {
+		for _, line := range l.Lines {
+			for _, w := range strings.Fields(line) {
+				if len(w) < 3 || len(w) > 8 || seen[w] || strings.Trim(w, "abcdefghijklmnopqrstuvwxyz") != "" {
+					continue
+				}
+				seen[w] = true
+				words = append(words, w)
+			}
+		}
+	}
+	return words
+}
+
+// fieldRow wraps one siFieldW-wide play field row in the box borders.
+func fieldRow(row string) string {
+	pad := boxW - 4 - vLen(row)
+	if pad < 0 {
+		pad = 0
+	}
+	return TTBg + TTBorder + "║ " + TTFg + row + TTFg + strings.Repeat(" ", pad) + TTBorder + " ║" + RST
+}
+
+// ════════════════════════════════════════════════════════════════════
+// Space Invaders adapter
+// ════════════════════════════════════════════════════════════════════
+
+// spaceInvaders plugs SpaceGame into the game framework, keeping the
+// terminal side effects (bell, rendering) out of the engine.
+type spaceInvaders struct{ *SpaceGame }
+
+func newSpaceInvaders(seed int64) Game { return spaceInvaders{newSpaceGame(seed)} }
+
+func (g spaceInvaders) ticker() Ticker { return newWallTicker(g.tickRate) }
+
+func (g spaceInvaders) update(u *ui) {
+	missed := g.missed
+	g.SpaceGame.update()
+	if g.missed > missed {
+		u.bell()
+	}
+}
+
+func (g spaceInvaders) render(u *ui, first bool) { u.renderSpaceGame(g.SpaceGame, first) }
+
+func (g spaceInvaders) finalScore() (int, bool) { return g.score, g.gameOver }
+
+func (g spaceInvaders) handleKey(u *ui, k keyEvent) string {
+	switch {
+	case g.gameOver:
+		if k.kind == evEscape {
+			return "menu"
+		}
+		if k.kind == evChar {
+			switch k.ch {
+			case 'r', 'R':
+				*g.SpaceGame = *newSpaceGame(g.seed)
+				g.render(u, true)
+			case 'm', 'M':
+				return "menu"
+			case 'q', 'Q':
+				return "quit"
+			}
+		}
+	case g.paused:
+		switch k.kind {
+		case evEscape, evEnter:
+			g.paused = false
+			g.render(u, false)
+		case evChar:
+			switch k.ch {
+			case 'm', 'M':
+				return "menu"
+			case 'q', 'Q':
+				return "quit"
+			}
+		}
+	case g.intermission:
+		switch k.kind {
+		case evEnter:
+			g.startWave(g.level + 1)
+			g.render(u, false)
+		case evChar:
+			switch k.ch {
+			case ' ':
+				g.startWave(g.level + 1)
+				g.render(u, false)
+			case 'm', 'M':
+				return "menu"
+			case 'q', 'Q':
+				return "quit"
+			}
+		}
+	default:
+		if k.kind == evEscape {
+			g.paused = true
+			g.render(u, false)
+		}
+		if k.kind == evChar {
+			ch := u.settings.remapKey(k).ch
+			if ch >= 'A' && ch <= 'Z' {
+				ch = ch - 'A' + 'a'
+			}
+			if g.tryShoot(ch) {
+				g.render(u, false)
+			}
+		}
+	}
+	return ""
+}
+
+// ════════════════════════════════════════════════════════════════════
+// Word Stack — falling words pile up Tetris-style
+// ════════════════════════════════════════════════════════════════════
+
+// stackWord is a word that landed and now sits on the pile.
+type stackWord struct {
+	word string
+	x    int
+}
+
+// WordStack drops one word at a time. Typing it clears it; a word that
+// lands joins the pile, and typing the top of the pile digs it out.
+// The game ends when the pile reaches the top of the field.
+type WordStack struct {
+	seed     int64
+	rng      *rand.Rand
+	tickRate time.Duration
+	pool     []string
+
+	falling string  // word currently dropping
+	fx      int     // its column
+	fy      float64 // its row (fractional)
+	stack   []stackWord
+	input   []rune
+
+	score    int
+	cleared  int
+	errors   int
+	speed    float64 // rows per tick
+	gameOver bool
+}
+
+func newWordStack(seed int64) Game { return newWordStackGame(seed) }
+
+func (g *WordStack) finalScore() (int, bool) { return g.score, g.gameOver }
+
+func newWordStackGame(seed int64) *WordStack {
+	g := &WordStack{
+		seed:     seed,
+		rng:      rand.New(rand.NewSource(seed)),
+		tickRate: 100 * time.Millisecond,
+		pool:     lessonWords(),
+		speed:    0.05,
+	}
+	g.spawn()
+	return g
+}
+
+func (g *WordStack) spawn() {
+	g.falling = g.pool[g.rng.Intn(len(g.pool))]
+	g.fx = g.rng.Intn(siFieldW-len(g.falling)-2) + 1
+	g.fy = 0
+	g.input = g.input[:0]
+}
+
+// floor is the row the falling word lands on.
+func (g *WordStack) floor() int { return siFieldH - 1 - len(g.stack) }
+
+func (g *WordStack) ticker() Ticker { return newWallTicker(g.tickRate) }
+
+func (g *WordStack) update(u *ui) {
+	if g.gameOver {
+		return
+	}
+	g.fy += g.speed
+	if int(g.fy) < g.floor() {
+		return
+	}
+	// landed — the word joins the pile
+	g.stack = append(g.stack, stackWord{g.falling, g.fx})
+	u.bell()
+	if g.floor() <= 0 {
+		g.gameOver = true
+		return
+	}
+	g.spawn()
+}
+
+// top returns the word on top of the pile, or "" when it is empty.
+func (g *WordStack) top() string {
+	if len(g.stack) == 0 {
+		return ""
+	}
+	return g.stack[len(g.stack)-1].word
+}
+
+// typeRune feeds one keystroke to the current input buffer.
+func (g *WordStack) typeRune(u *ui, r rune) {
+	g.input = append(g.input, r)
+	in := string(g.input)
+	switch {
+	case in == g.falling:
+		g.score += 10 * len(g.falling)
+		g.cleared++
+		g.speed = 0.05 + 0.01*float64(g.cleared/5)
+		g.spawn()
+	case in == g.top():
+		g.score += 5 * len(in)
+		g.stack = g.stack[:len(g.stack)-1]
+		g.input = g.input[:0]
+	case strings.HasPrefix(g.falling, in), g.top() != "" && strings.HasPrefix(g.top(), in):
+		// still a valid prefix
+	default:
+		g.errors++
+		g.input = g.input[:0]
+		u.bell()
+	}
+}
+
+func (g *WordStack) handleKey(u *ui, k keyEvent) string {
+	if g.gameOver {
+		if k.kind == evEscape {
+			return "menu"
+		}
+		if k.kind == evChar {
+			switch k.ch {
+			case 'r', 'R':
+				*g = *newWordStackGame(g.seed)
+				g.render(u, true)
+			case 'm', 'M':
+				return "menu"
+			case 'q', 'Q':
+				return "quit"
+			}
+		}
+		return ""
+	}
+	switch k.kind {
+	case evEscape:
+		return "menu"
+	case evBackspace:
+		if len(g.input) > 0 {
+			g.input = g.input[:len(g.input)-1]
+		}
+	case evChar:
+		if k.ch != ' ' {
+			g.typeRune(u, u.settings.remapKey(k).ch)
+		}
+	}
+	g.render(u, false)
+	return ""
+}
+
+// wordCells paints word at column x of row, highlighting the typed prefix.
+func wordCells(row []string, word string, x int, typed int, color string) {
+	for i, r := range word {
+		c := x + i
+		if c < 0 || c >= len(row) {
+			continue
+		}
+		if i < typed {
+			row[c] = FgGrn + BOLD + string(r) + RST + TTBg
+		} else {
+			row[c] = color + string(r) + RST + TTBg
+		}
+	}
+}
+
+func (g *WordStack) render(u *ui, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	in := string(g.input)
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"** WORD STACK -- Type Before It Lands! **"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(fmt.Sprintf(
+		"Score:%s%5d%s  Cleared:%s%d%s  Pile:%s%d/%d%s  Errors:%s%d%s",
+		FgYlw+BOLD, g.score, RST+TTBg,
+		FgGrn+BOLD, g.cleared, RST+TTBg,
+		FgCyn+BOLD, len(g.stack), siFieldH-1, RST+TTBg,
+		FgRed+BOLD, g.errors, RST+TTBg,
+	)) + "\n")
+	b.WriteString(hMid() + "\n")
+
+	field := make([][]string, siFieldH)
+	for r := range field {
+		field[r] = make([]string, siFieldW)
+		for c := range field[r] {
+			field[r][c] = " "
+		}
+	}
+	for i, sw := range g.stack {
+		typed := 0
+		if i == len(g.stack)-1 && strings.HasPrefix(sw.word, in) {
+			typed = len(in)
+		}
+		wordCells(field[siFieldH-1-i], sw.word, sw.x, typed, TTDim)
+	}
+	if !g.gameOver {
+		typed := 0
+		if strings.HasPrefix(g.falling, in) {
+			typed = len(in)
+		}
+		if r := int(g.fy); r >= 0 && r < siFieldH {
+			wordCells(field[r], g.falling, g.fx, typed, FgYlw+BOLD)
+		}
+	}
+	for _, row := range field {
+		b.WriteString(fieldRow(strings.Join(row, "")) + "\n")
+	}
+
+	b.WriteString(hMid() + "\n")
+	if g.gameOver {
+		b.WriteString(hCenter(BOLD+FgRed+"THE PILE REACHED THE TOP!"+RST+TTBg) + "\n")
+		b.WriteString(hRow(fmt.Sprintf("Final Score: %s%d%s   Cleared: %s%d%s   Errors: %s%d%s",
+			FgYlw+BOLD, g.score, RST+TTBg,
+			FgGrn+BOLD, g.cleared, RST+TTBg,
+			FgRed+BOLD, g.errors, RST+TTBg)) + "\n")
+		b.WriteString(hRow(TTDim+"R=Restart │ M=Menu │ Q=Quit"+RST+TTBg) + "\n")
+	} else {
+		b.WriteString(hRow(fmt.Sprintf("Input: %s%s%s", FgCyn+BOLD, in, RST+TTBg)) + "\n")
+		b.WriteString(hRow(TTDim+"Type the falling word, or the top of the pile │ ESC=Menu"+RST+TTBg) + "\n")
+	}
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+// ════════════════════════════════════════════════════════════════════
+// Typing Racer — your car moves at your typing speed
+// ════════════════════════════════════════════════════════════════════
+
+const racerTrackW = 50 // track length in columns
+
+// racerTexts are the passages raced over; one is picked per race.
+var racerTexts = []string{
+	"the quick brown fox jumps over the lazy dog while the farmer watches from the old wooden gate",
+	"good typing speed comes from daily practice so keep your fingers on the home row and your eyes on the text",
+	"a journey of a thousand miles begins with a single step and the first step is always the hardest one",
+	"she sells sea shells by the sea shore and the shells she sells are surely sea shells from the shore",
+	"focus on accuracy first then build speed because every mistake costs more time than a careful keystroke",
+	"the meeting has been moved to next monday so please make sure all the files are saved before friday",
+}
+
+// paceCar is a computer opponent driving at a constant speed.
+type paceCar struct {
+	Name string
+	WPM  float64
+}
+
+var racerPace = []paceCar{
+	{"Turtle", 20},
+	{"Rabbit", 40},
+	{"Cheetah", 70},
+}
+
+// Racer is a race over one passage against pace cars. Time is counted
+// in ticks, so a race is reproducible from its seed and keystrokes.
+type Racer struct {
+	seed     int64
+	rng      *rand.Rand
+	tickRate time.Duration
+
+	text    []rune
+	pos     int // runes typed correctly
+	errors  int
+	started bool
+	ticks   int // ticks since the first keystroke
+	done    bool
+}
+
+func newRacer(seed int64) Game { return newRacerGame(seed) }
+
+func newRacerGame(seed int64) *Racer {
+	g := &Racer{
+		seed:     seed,
+		rng:      rand.New(rand.NewSource(seed)),
+		tickRate: 100 * time.Millisecond,
+	}
+	g.text = []rune(racerTexts[g.rng.Intn(len(racerTexts))])
+	return g
+}
+
+// finalScore of a race is the speed it finished at.
+func (g *Racer) finalScore() (int, bool) { return int(g.wpm() + 0.5), g.done }
+
+func (g *Racer) elapsed() time.Duration { return time.Duration(g.ticks) * g.tickRate }
+
+// wpm is the player's current speed, which is also their car's speed.
+func (g *Racer) wpm() float64 {
+	m := g.elapsed().Minutes()
+	if m <= 0 {
+		return 0
+	}
+	return float64(g.pos) / 5 / m
+}
+
+// paceProgress returns how far along the track pace car p is, 0..1.
+func (g *Racer) paceProgress(p paceCar) float64 {
+	done := p.WPM * 5 * g.elapsed().Minutes() / float64(len(g.text))
+	if done > 1 {
+		done = 1
+	}
+	return done
+}
+
+// paceTime is how long pace car p needs for the whole passage.
+func (g *Racer) paceTime(p paceCar) time.Duration {
+	return time.Duration(float64(len(g.text)) / (p.WPM * 5) * float64(time.Minute))
+}
+
+func (g *Racer) ticker() Ticker { return newWallTicker(g.tickRate) }
+
+func (g *Racer) update(u *ui) {
+	if g.started && !g.done {
+		g.ticks++
+	}
+}
+
+func (g *Racer) handleKey(u *ui, k keyEvent) string {
+	if g.done {
+		if k.kind == evEscape {
+			return "menu"
+		}
+		if k.kind == evChar {
+			switch k.ch {
+			case 'r', 'R':
+				*g = *newRacerGame(g.seed)
+				g.render(u, true)
+			case 'm', 'M':
+				return "menu"
+			case 'q', 'Q':
+				return "quit"
+			}
+		}
+		return ""
+	}
+	switch k.kind {
+	case evEscape:
+		return "menu"
+	case evChar:
+		g.started = true
+		if u.settings.remapKey(k).ch == g.text[g.pos] {
+			g.pos++
+			if g.pos == len(g.text) {
+				g.done = true
+			}
+		} else {
+			g.errors++
+			u.bell()
+		}
+		g.render(u, false)
+	}
+	return ""
+}
+
+// ranking returns the finishing order, the player included as "You".
+func (g *Racer) ranking() []string {
+	type entry struct {
+		name string
+		t    time.Duration
+	}
+	list := []entry{{"You", g.elapsed()}}
+	for _, p := range racerPace {
+		list = append(list, entry{p.Name, g.paceTime(p)})
+	}
+	for i := 1; i < len(list); i++ {
+		for j := i; j > 0 && list[j].t < list[j-1].t; j-- {
+			list[j], list[j-1] = list[j-1], list[j]
+		}
+	}
+	var out []string
+	for i, e := range list {
+		out = append(out, fmt.Sprintf("%d. %-8s %5.1fs", i+1, e.name, e.t.Seconds()))
+	}
+	return out
+}
+
+// lane draws one car on the track at progress p (0..1).
+func lane(name string, p float64, color string, speed float64) string {
+	at := int(p * float64(racerTrackW-2))
+	track := strings.Repeat("·", at) + color + BOLD + "=>" + RST + TTBg + TTDim +
+		strings.Repeat("·", racerTrackW-2-at) + RST + TTBg
+	return fmt.Sprintf("%-8s %s|%s%s|%s %s%3.0f WPM%s",
+		name, TTBorder, track, TTBorder, TTFg, color, speed, RST+TTBg)
+}
+
+func (g *Racer) render(u *ui, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"** TYPING RACER -- Your Speed Drives the Car **"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	el := g.elapsed()
+	b.WriteString(hRow(fmt.Sprintf("Time:%s%5.1fs%s  Speed:%s%.0f WPM%s  Errors:%s%d%s",
+		FgYlw, el.Seconds(), RST+TTBg,
+		FgGrn+BOLD, g.wpm(), RST+TTBg,
+		FgRed+BOLD, g.errors, RST+TTBg)) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hRow(lane("You", float64(g.pos)/float64(len(g.text)), FgCyn, g.wpm())) + "\n")
+	for _, p := range racerPace {
+		b.WriteString(hRow(lane(p.Name, g.paceProgress(p), FgYlw, p.WPM)) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+
+	// passage, wrapped to the box, typed part green and caret inverted
+	for _, sp := range wrapSpans(strings.Split(string(g.text), ""), boxW-6) {
+		var line strings.Builder
+		for i := sp[0]; i < sp[1]; i++ {
+			switch {
+			case i < g.pos:
+				line.WriteString(FgGrn + string(g.text[i]) + RST + TTBg)
+			case i == g.pos:
+				line.WriteString(REV + string(g.text[i]) + RST + TTBg)
+			default:
+				line.WriteString(TTFg + string(g.text[i]))
+			}
+		}
+		b.WriteString(hRow(line.String()) + "\n")
+	}
+	b.WriteString(hMid() + "\n")
+	if g.done {
+		b.WriteString(hCenter(BOLD+TTHilite+"FINISH!"+RST+TTBg) + "\n")
+		for _, r := range g.ranking() {
+			b.WriteString(hCenter(r) + "\n")
+		}
+		b.WriteString(hRow(TTDim+"R=New Race │ M=Menu │ Q=Quit"+RST+TTBg) + "\n")
+	} else {
+		b.WriteString(hRow(TTDim+"Type the passage to drive │ ESC=Menu"+RST+TTBg) + "\n")
+	}
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
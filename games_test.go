@@ -0,0 +1,162 @@
+package main
+
+import "testing"
+
+func typeWord(g Game, u *ui, w string) {
+	for _, r := range w {
+		g.handleKey(u, keyEvent{kind: evChar, ch: r})
+	}
+}
+
+func TestWordStackSameSeedSameWords(t *testing.T) {
+	a, b := newWordStackGame(42), newWordStackGame(42)
+	for i := 0; i < 20; i++ {
+		if a.falling != b.falling || a.fx != b.fx {
+			t.Fatalf("word %d: %q at %d vs %q at %d", i, a.falling, a.fx, b.falling, b.fx)
+		}
+		a.spawn()
+		b.spawn()
+	}
+}
+
+func TestWordStackClearFalling(t *testing.T) {
+	u := &ui{term: newFakeTerm()}
+	g := newWordStackGame(1)
+	w := g.falling
+	typeWord(g, u, w)
+	if g.cleared != 1 || g.score != 10*len(w) || len(g.input) != 0 {
+		t.Fatalf("after typing %q: cleared=%d score=%d input=%q", w, g.cleared, g.score, string(g.input))
+	}
+	if g.fy != 0 {
+		t.Fatalf("next word starts at row %v, want 0", g.fy)
+	}
+}
+
+func TestWordStackLandAndDigOut(t *testing.T) {
+	u := &ui{term: newFakeTerm()}
+	g := newWordStackGame(2)
+	w := g.falling
+	for i := 0; len(g.stack) == 0 && i < 1000; i++ {
+		g.update(u)
+	}
+	if len(g.stack) != 1 || g.top() != w {
+		t.Fatalf("pile %+v, want %q on it", g.stack, w)
+	}
+	if g.falling == w {
+		g.falling = "zzzz" // keep the dig-out from matching the falling word
+	}
+	typeWord(g, u, w)
+	if len(g.stack) != 0 || g.score != 5*len(w) {
+		t.Fatalf("after digging out %q: pile %+v, score %d", w, g.stack, g.score)
+	}
+}
+
+func TestWordStackWrongKey(t *testing.T) {
+	u := &ui{term: newFakeTerm()}
+	g := newWordStackGame(3)
+	g.handleKey(u, keyEvent{kind: evChar, ch: rune(g.falling[0])})
+	g.handleKey(u, keyEvent{kind: evChar, ch: '#'})
+	if g.errors != 1 || len(g.input) != 0 {
+		t.Fatalf("errors=%d input=%q, want 1 and empty", g.errors, string(g.input))
+	}
+}
+
+func TestWordStackGameOverAndRestart(t *testing.T) {
+	u := &ui{term: newFakeTerm()}
+	g := newWordStackGame(4)
+	first := g.falling
+	for i := 0; !g.gameOver && i < 100000; i++ {
+		g.update(u)
+	}
+	if !g.gameOver || len(g.stack) != siFieldH-1 {
+		t.Fatalf("gameOver=%v with %d words piled", g.gameOver, len(g.stack))
+	}
+	if act := g.handleKey(u, keyEvent{kind: evChar, ch: 'r'}); act != "" {
+		t.Fatalf("restart returned %q", act)
+	}
+	if g.gameOver || len(g.stack) != 0 || g.falling != first {
+		t.Fatalf("after restart: gameOver=%v pile %d, first word %q want %q", g.gameOver, len(g.stack), g.falling, first)
+	}
+}
+
+func TestRacerClockStartsOnFirstKey(t *testing.T) {
+	u := &ui{term: newFakeTerm()}
+	g := newRacerGame(1)
+	for i := 0; i < 10; i++ {
+		g.update(u)
+	}
+	if g.ticks != 0 {
+		t.Fatalf("%d ticks counted before the first key", g.ticks)
+	}
+	g.handleKey(u, keyEvent{kind: evChar, ch: g.text[0]})
+	g.update(u)
+	if g.ticks != 1 || g.pos != 1 {
+		t.Fatalf("ticks=%d pos=%d, want 1, 1", g.ticks, g.pos)
+	}
+}
+
+func TestRacerWrongKey(t *testing.T) {
+	u := &ui{term: newFakeTerm()}
+	g := newRacerGame(2)
+	g.handleKey(u, keyEvent{kind: evChar, ch: '#'})
+	if g.errors != 1 || g.pos != 0 {
+		t.Fatalf("errors=%d pos=%d, want 1, 0", g.errors, g.pos)
+	}
+}
+
+func TestRacerFinish(t *testing.T) {
+	u := &ui{term: newFakeTerm()}
+	g := newRacerGame(3)
+	// one key per tick: 10 keys a second is 120 WPM, less the tick the
+	// last key ends the race in
+	for _, r := range g.text {
+		g.handleKey(u, keyEvent{kind: evChar, ch: r})
+		g.update(u)
+	}
+	if !g.done {
+		t.Fatal("race not done after typing the whole passage")
+	}
+	if wpm, _ := g.finalScore(); wpm < 120 || wpm > 123 {
+		t.Fatalf("finished at %d WPM, want about 120", wpm)
+	}
+	if r := g.ranking(); r[0][3:6] != "You" {
+		t.Fatalf("at 120 WPM the ranking is %v", r)
+	}
+	ticks := g.ticks
+	g.update(u)
+	if g.ticks != ticks {
+		t.Fatal("update advanced the clock after the finish")
+	}
+}
+
+func TestRacerRestartKeepsSeed(t *testing.T) {
+	u := &ui{term: newFakeTerm()}
+	g := newRacerGame(5)
+	text := string(g.text)
+	typeWord(g, u, text)
+	if act := g.handleKey(u, keyEvent{kind: evChar, ch: 'r'}); act != "" {
+		t.Fatalf("restart returned %q", act)
+	}
+	if g.done || g.pos != 0 || string(g.text) != text {
+		t.Fatalf("after restart: done=%v pos=%d text %q, want %q", g.done, g.pos, string(g.text), text)
+	}
+}
+
+func TestGameRemapSparesControlKeys(t *testing.T) {
+	u := &ui{term: newFakeTerm(), settings: Settings{Layout: 2, Remap: true}} // Colemak
+	g := newRacerGame(6)
+	g.text = []rune("rr")
+	g.handleKey(u, keyEvent{kind: evChar, ch: 's', text: "s"})
+	if g.pos != 1 {
+		t.Fatalf("QWERTY s did not type Colemak r: pos=%d errors=%d", g.pos, g.errors)
+	}
+	g.handleKey(u, keyEvent{kind: evChar, ch: 's', text: "s"})
+	if !g.done {
+		t.Fatal("race not done")
+	}
+	// QWERTY r is Colemak p, which the results screen ignores
+	g.handleKey(u, keyEvent{kind: evChar, ch: 'r', text: "r"})
+	if g.done || g.pos != 0 {
+		t.Fatalf("R on the results did not restart: done=%v pos=%d", g.done, g.pos)
+	}
+}
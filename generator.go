@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Word generator — endless lines from word-frequency lists
+// ════════════════════════════════════════════════════════════════════
+
+// genLines is how many lines each generated lesson has; finishing one
+// and pressing R on the results screen deals the next.
+const genLines = 6
+
+// genWidth is the widest line hRow shows without clipping.
+const genWidth = boxW - 4
+
+// wordList is a language's words, most common first.
+type wordList struct {
+	Name  string
+	words []string
+}
+
+var wordLists = []wordList{
+	{"English", strings.Fields(enWords)},
+	{"Spanish", strings.Fields(esWords)},
+	{"French", strings.Fields(frWords)},
+	{"German", strings.Fields(deWords)},
+}
+
+// genSizes are the tiers a list is offered in, as far as it reaches.
+var genSizes = []int{200, 1000, 5000}
+
+// generatorLessons are the lessons of the word generator track: one per
+// list and tier. They carry no lines of their own; next deals them.
+func generatorLessons() []Lesson {
+	var out []Lesson
+	for _, wl := range wordLists {
+		for _, n := range genSizes {
+			if n > len(wl.words) {
+				break
+			}
+			words, name := wl.words[:n], fmt.Sprintf("%s — top %d words", wl.Name, n)
+			out = append(out, Lesson{
+				Name: name,
+				next: func(st *Settings) *Lesson {
+					return &Lesson{Name: name, Lines: genText(words, st, rand.New(rand.NewSource(gameSeed())))}
+				},
+			})
+		}
+	}
+	return out
+}
+
+// genText deals genLines lines of random words, shaped into sentences
+// by the generator options in st.
+func genText(words []string, st *Settings, rng *rand.Rand) []string {
+	left := 0 // words left in the current sentence
+	return fillLines(func() string {
+		start := left == 0
+		if start {
+			left = 4 + rng.Intn(9)
+		}
+		left--
+		w := words[rng.Intn(len(words))]
+		if st.GenNumbers && rng.Intn(10) == 0 {
+			w = genNumber(rng, st.GenPunct)
+		}
+		if st.GenCaps && (start || rng.Intn(15) == 0) {
+			w = capitalize(w)
+		}
+		if st.GenPunct {
+			switch {
+			case left == 0:
+				w += [...]string{".", ".", ".", ".", "?", "!"}[rng.Intn(6)]
+			case rng.Intn(8) == 0:
+				w += [...]string{",", ",", ",", ";", ":"}[rng.Intn(5)]
+			case rng.Intn(25) == 0:
+				w = [...]string{`"`, "(", "'"}[rng.Intn(3)] + w + [...]string{`"`, ")", "'"}[rng.Intn(3)]
+			}
+		}
+		return w
+	})
+}
+
+// fillLines deals genLines lines of the words next returns, as many as
+// fit in genWidth columns each.
+func fillLines(next func() string) []string {
+	var lines []string
+	var line strings.Builder
+	for len(lines) < genLines {
+		w := next()
+		n := vLen(line.String())
+		if n > 0 && n+1+vLen(w) > genWidth {
+			lines = append(lines, line.String())
+			line.Reset()
+			n = 0
+		}
+		if n > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(w)
+	}
+	return lines
+}
+
+// genNumber is a number as it turns up in prose: a count, a year and,
+// with punctuation, a price, a time, a percentage or a decimal.
+func genNumber(rng *rand.Rand, punct bool) string {
+	kind := rng.Intn(6)
+	if !punct {
+		kind %= 2
+	}
+	switch kind {
+	case 0:
+		return fmt.Sprint(rng.Intn(100))
+	case 1:
+		return fmt.Sprint(1900 + rng.Intn(131))
+	case 2:
+		return fmt.Sprintf("$%d", 1+rng.Intn(500))
+	case 3:
+		return fmt.Sprintf("%d:%02d", 1+rng.Intn(12), rng.Intn(60))
+	case 4:
+		return fmt.Sprintf("%d%%", rng.Intn(101))
+	default:
+		return fmt.Sprintf("%d.%d", rng.Intn(10), rng.Intn(10))
+	}
+}
+
+func capitalize(w string) string {
+	r, n := utf8.DecodeRuneInString(w)
+	return string(unicode.ToUpper(r)) + w[n:]
+}
+
+func onOff(on bool) string {
+	if on {
+		return "On"
+	}
+	return "Off"
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestWordListsHaveNoDuplicates(t *testing.T) {
+	for _, wl := range wordLists {
+		seen := map[string]bool{}
+		for _, w := range wl.words {
+			if seen[w] {
+				t.Errorf("%s: %q listed twice", wl.Name, w)
+			}
+			seen[w] = true
+		}
+	}
+	if n := len(wordLists[0].words); n != 5000 {
+		t.Errorf("English list has %d words, want 5000", n)
+	}
+}
+
+func TestGenTextPlainWords(t *testing.T) {
+	words := wordLists[0].words[:200]
+	top := map[string]bool{}
+	for _, w := range words {
+		top[w] = true
+	}
+	lines := genText(words, &Settings{}, rand.New(rand.NewSource(1)))
+	if len(lines) != genLines {
+		t.Fatalf("got %d lines, want %d", len(lines), genLines)
+	}
+	for _, line := range lines {
+		if n := vLen(line); n > genWidth || n < genWidth-20 {
+			t.Errorf("%q is %d columns, want up to %d and well filled", line, n, genWidth)
+		}
+		for _, w := range strings.Fields(line) {
+			if !top[w] {
+				t.Errorf("%q is not in the top 200", w)
+			}
+		}
+	}
+}
+
+func TestGenTextOptions(t *testing.T) {
+	st := &Settings{GenPunct: true, GenCaps: true, GenNumbers: true}
+	var text string
+	for seed := int64(1); seed <= 5; seed++ {
+		for _, line := range genText(wordLists[0].words, st, rand.New(rand.NewSource(seed))) {
+			if n := vLen(line); n > genWidth {
+				t.Errorf("%q is %d columns, want at most %d", line, n, genWidth)
+			}
+			text += line + " "
+		}
+	}
+	if !unicode.IsUpper([]rune(text)[0]) {
+		t.Errorf("text starts %q, want a capital", text[:10])
+	}
+	for _, want := range []string{".", ",", "0123456789"} {
+		if !strings.ContainsAny(text, want) {
+			t.Errorf("no %q in %q", want, text)
+		}
+	}
+	if !strings.ContainsAny(text, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		t.Error("no capitals with GenCaps on")
+	}
+}
+
+func TestGeneratedLessonDealsFreshLines(t *testing.T) {
+	ls := generatorLessons()
+	if len(ls) != 6 {
+		t.Fatalf("got %d generator lessons, want 6", len(ls))
+	}
+	for i, want := range []string{"English — top 200 words", "English — top 1000 words", "English — top 5000 words"} {
+		if ls[i].Name != want {
+			t.Errorf("lesson %d is %q, want %q", i, ls[i].Name, want)
+		}
+	}
+	st := &Settings{}
+	a := st.practiceLesson(&ls[0])
+	b := st.practiceLesson(a)
+	if len(a.Lines) != genLines || a.Name != ls[0].Name || b.next == nil {
+		t.Fatalf("practice lesson %q has %d lines", a.Name, len(a.Lines))
+	}
+	if strings.Join(a.Lines, "\n") == strings.Join(b.Lines, "\n") {
+		t.Error("retrying a generated lesson dealt the same lines")
+	}
+}
@@ -2,6 +2,10 @@ module github.com/xtaci/tt
 
 go 1.22
 
-require golang.org/x/term v0.29.0
+require (
+	golang.org/x/crypto v0.33.0
+	golang.org/x/term v0.29.0
+	golang.org/x/text v0.22.0
+)
 
 require golang.org/x/sys v0.30.0 // indirect
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Golden-frame tests drive the whole state machine through a fake
+// terminal and compare the screens it draws with testdata/golden.
+// After an intended change to a screen, regenerate them with
+//
+//	go test -run Golden -update
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// screen is a minimal terminal emulator: it keeps the characters on a
+// grid and understands the cursor moves and clears tt emits. Colours
+// and other attributes are dropped.
+type screen struct {
+	cells    [][]rune
+	row, col int
+}
+
+func newScreen(w, h int) *screen {
+	s := &screen{cells: make([][]rune, h)}
+	for i := range s.cells {
+		s.cells[i] = make([]rune, w)
+	}
+	s.clear()
+	return s
+}
+
+func (s *screen) clear() {
+	for _, row := range s.cells {
+		for i := range row {
+			row[i] = ' '
+		}
+	}
+}
+
+// feed interprets terminal output.
+func (s *screen) feed(out string) {
+	rs := []rune(out)
+	for i := 0; i < len(rs); i++ {
+		switch r := rs[i]; {
+		case r == '\033' && i+1 < len(rs) && rs[i+1] == '[':
+			j := i + 2
+			for j < len(rs) && (rs[j] < '@' || rs[j] > '~') {
+				j++
+			}
+			if j < len(rs) {
+				s.csi(string(rs[i+2:j]), rs[j])
+			}
+			i = j
+		case r == '\033':
+			i++ // two-byte escape
+		case r == '\r':
+			s.col = 0
+		case r == '\n':
+			s.row++
+		case r < ' ':
+			// bell and other controls draw nothing
+		default:
+			s.put(r)
+		}
+	}
+}
+
+func (s *screen) csi(params string, cmd rune) {
+	switch cmd {
+	case 'H':
+		row, col := 1, 1
+		if p := strings.Split(params, ";"); params != "" {
+			row, _ = strconv.Atoi(p[0])
+			if len(p) > 1 {
+				col, _ = strconv.Atoi(p[1])
+			}
+		}
+		s.row, s.col = row-1, col-1
+	case 'J':
+		if params == "2" {
+			s.clear()
+		}
+	}
+}
+
+func (s *screen) put(r rune) {
+	if s.row < 0 || s.row >= len(s.cells) {
+		return
+	}
+	line := s.cells[s.row]
+	w := runeWidth(r)
+	if w == 0 {
+		return // combining marks ride on the previous cell
+	}
+	if s.col+w > len(line) {
+		s.col += w
+		return
+	}
+	line[s.col] = r
+	if w == 2 {
+		line[s.col+1] = 0 // covered by the wide rune
+	}
+	s.col += w
+}
+
+func (s *screen) String() string {
+	var b strings.Builder
+	for _, row := range s.cells {
+		var line strings.Builder
+		for _, r := range row {
+			if r != 0 {
+				line.WriteRune(r)
+			}
+		}
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return b.String()
+}
+
+// stepTicker is a game clock the test advances by hand.
+type stepTicker chan time.Time
+
+func (t stepTicker) C() <-chan time.Time { return t }
+func (t stepTicker) Stop()               {}
+
+// scenario runs the menu on a fake terminal and records the screen at
+// the points the test asks for.
+type scenario struct {
+	t      *testing.T
+	term   *fakeTerm
+	screen *screen
+	tick   stepTicker
+	done   chan error
+	frames strings.Builder
+}
+
+// scenarioStart is when every scenario's clock starts.
+var scenarioStart = time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local)
+
+// newScenario starts the menu; setup can prepare the ui first.
+func newScenario(t *testing.T, setup ...func(u *ui)) *scenario {
+	t.Helper()
+	// a clock that moves one second per reading keeps speeds stable
+	base := scenarioStart
+	n := 0
+	now = func() time.Time { n++; return base.Add(time.Duration(n) * time.Second) }
+	*seedFlag = 1
+	t.Cleanup(func() { now, *seedFlag = time.Now, 0 })
+
+	sc := &scenario{t: t, term: newFakeTerm(), tick: make(stepTicker), done: make(chan error, 1)}
+	sc.screen = newScreen(sc.term.w, sc.term.h)
+	u := &ui{term: sc.term, tick: sc.tick}
+	for _, f := range setup {
+		f(u)
+	}
+	go func() {
+		u.hideCur()
+		err := u.runMenu(sc.term.Events())
+		sc.term.Close()
+		sc.done <- err
+	}()
+	sc.press() // wait for the first frame
+	return sc
+}
+
+// press sends keys and takes in what the ui drew for them. With no
+// keys it only waits for the ui to be idle.
+func (sc *scenario) press(ks ...keyEvent) {
+	if len(ks) == 0 {
+		ks = []keyEvent{{kind: evNone}}
+	}
+	sc.term.press(ks...)
+	sc.screen.feed(sc.term.output())
+}
+
+func (sc *scenario) typeText(s string) {
+	sc.term.typeText(s)
+	sc.screen.feed(sc.term.output())
+}
+
+func (sc *scenario) char(r rune) keyEvent { return keyEvent{kind: evChar, ch: r, text: string(r)} }
+
+// ticks advances a running game by n ticks.
+func (sc *scenario) ticks(n int) {
+	for i := 0; i < n; i++ {
+		sc.tick <- time.Time{}
+		sc.press()
+	}
+}
+
+// ticksUntil advances a running game until cond holds on the screen.
+func (sc *scenario) ticksUntil(max int, cond func(screen string) bool) {
+	sc.t.Helper()
+	for i := 0; i < max; i++ {
+		if cond(sc.screen.String()) {
+			return
+		}
+		sc.ticks(1)
+	}
+	sc.t.Fatalf("condition not met after %d ticks:\n%s", max, sc.screen)
+}
+
+// snap records the current screen under a label.
+func (sc *scenario) snap(label string) {
+	fmt.Fprintf(&sc.frames, "──── %s ────\n%s", label, sc.screen)
+}
+
+// finish quits with Ctrl-C and compares the recorded frames with the
+// golden file of the test.
+func (sc *scenario) finish() {
+	sc.t.Helper()
+	sc.press(keyEvent{kind: evCtrlC})
+	select {
+	case err := <-sc.done:
+		if err != nil {
+			sc.t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		sc.t.Fatal("runMenu did not return")
+	}
+	compareGolden(sc.t, sc.frames.String())
+}
+
+func compareGolden(t *testing.T, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", strings.TrimPrefix(t.Name(), "TestGolden")+".txt")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		gl, wl := strings.Split(got, "\n"), strings.Split(string(want), "\n")
+		for i := 0; i < len(gl) || i < len(wl); i++ {
+			var g, w string
+			if i < len(gl) {
+				g = gl[i]
+			}
+			if i < len(wl) {
+				w = wl[i]
+			}
+			if g != w {
+				t.Fatalf("%s differs at line %d:\n got: %q\nwant: %q\n(run go test -update if the change is intended)", path, i+1, g, w)
+			}
+		}
+	}
+}
+
+func TestGoldenMenu(t *testing.T) {
+	sc := newScenario(t)
+	sc.snap("start")
+	sc.press(keyEvent{kind: evDown}, keyEvent{kind: evDown})
+	sc.snap("down twice")
+	sc.press(keyEvent{kind: evRight})
+	sc.snap("right to games")
+	sc.press(keyEvent{kind: evDown})
+	sc.snap("down")
+	sc.press(keyEvent{kind: evLeft}, keyEvent{kind: evUp})
+	sc.snap("back left and up")
+	sc.finish()
+}
+
+func TestGoldenMenuScroll(t *testing.T) {
+	// a long homework pack and a corpus overfill the tracks
+	saved := tracks
+	t.Cleanup(func() { tracks = saved })
+	pack := Track{Name: "Homework: Week 9"}
+	for i := 1; i <= 20; i++ {
+		pack.Lessons = append(pack.Lessons, Lesson{Name: fmt.Sprintf("Spelling %d", i), Lines: []string{"their there"}})
+	}
+	tracks = append(tracks[:len(tracks):len(tracks)], pack, Track{Name: "Corpus: notes"})
+	sc := newScenario(t)
+	sc.press(keyEvent{kind: evRight})
+	sc.snap("tracks fill the column")
+	for i := 0; i < len(menuItems())-len(lessons)-1; i++ {
+		sc.press(keyEvent{kind: evDown})
+	}
+	sc.snap("scrolled to the last track")
+	sc.press(keyEvent{kind: evUp})
+	sc.press(keyEvent{kind: evEnter})
+	sc.snap("long track")
+	for i := 0; i < 19; i++ {
+		sc.press(keyEvent{kind: evDown})
+	}
+	sc.snap("long track scrolled")
+	sc.press(keyEvent{kind: evEscape})
+	sc.finish()
+}
+
+func TestGoldenTyping(t *testing.T) {
+	sc := newScenario(t)
+	sc.press(keyEvent{kind: evEnter})
+	sc.snap("lesson 1")
+	sc.typeText("asdf")
+	sc.snap("four right")
+	sc.typeText("xk")
+	sc.snap("two wrong")
+	sc.press(keyEvent{kind: evBackspace}, keyEvent{kind: evBackspace})
+	sc.snap("backspaced")
+	sc.typeText(" jkl;")
+	sc.snap("corrected")
+	sc.finish()
+}
+
+func TestGoldenLesson(t *testing.T) {
+	sc := newScenario(t)
+	sc.press(keyEvent{kind: evEnter})
+	lines := lessons[0].Lines
+	// one mistake on the first line, the rest typed clean
+	sc.typeText("q" + lines[0][1:])
+	sc.snap("line complete")
+	sc.press(keyEvent{kind: evEnter})
+	for _, line := range lines[1 : len(lines)-1] {
+		sc.typeText(line)
+		sc.press(keyEvent{kind: evEnter})
+	}
+	sc.snap("last line")
+	sc.typeText(lines[len(lines)-1])
+	sc.snap("last line complete")
+	sc.press(keyEvent{kind: evEnter})
+	sc.snap("results")
+	sc.press(sc.char('r'))
+	sc.snap("retry")
+	sc.press(keyEvent{kind: evEscape})
+	sc.snap("back to menu")
+	sc.finish()
+}
+
+func TestGoldenSpaceInvaders(t *testing.T) {
+	sc := newScenario(t)
+	sc.press(keyEvent{kind: evRight}, keyEvent{kind: evEnter})
+	sc.snap("start")
+	sc.ticks(40)
+	sc.snap("40 ticks")
+	sc.ticksUntil(20000, func(s string) bool { return strings.Contains(s, "GAME OVER") })
+	sc.snap("game over")
+	sc.press(sc.char('m'))
+	sc.snap("menu")
+	sc.finish()
+}
+
+func TestGoldenProgress(t *testing.T) {
+	h := newHistory(filepath.Join(t.TempDir(), "history.jsonl"))
+	for i := 0; i < 12; i++ {
+		lesson := []string{"Lesson 1", "Lesson 2"}[i%2]
+		h.add(Result{
+			Time:   scenarioStart.AddDate(0, 0, -11+i/2*2).Add(time.Duration(i) * time.Minute),
+			Lesson: lesson, Seconds: float64(60 * (i + 1)),
+			WPM: float64(10 + 2*i), Accuracy: float64(88 + i%5*3),
+		})
+	}
+	// run loads the history before the menu; the scenario starts at the menu
+	sc := newScenario(t, func(u *ui) {
+		u.history = h
+		var err error
+		if u.results, err = h.load(); err != nil || len(u.results) != 12 {
+			t.Fatalf("seeded history: %d results, %v", len(u.results), err)
+		}
+	})
+	sc.press(sc.char('p'))
+	sc.snap("overall")
+	sc.press(keyEvent{kind: evRight})
+	sc.snap("lesson 1")
+	sc.press(keyEvent{kind: evLeft}, keyEvent{kind: evLeft})
+	sc.snap("wraps to lesson 2")
+	sc.press(keyEvent{kind: evEscape})
+	sc.snap("menu")
+	sc.finish()
+}
+
+func TestGoldenCurriculum(t *testing.T) {
+	// the scenario clock types at 60 CPM
+	target := lessons[1].TargetCPM
+	lessons[1].TargetCPM = 50
+	t.Cleanup(func() { lessons[1].TargetCPM = target })
+	sc := newScenario(t, func(u *ui) {
+		u.settings.Curriculum = true
+		u.results = []Result{
+			{Lesson: lessons[0].Name, CPM: 100, Accuracy: 97},
+			{Lesson: lessons[1].Name, CPM: 200, Accuracy: 80}, // too many errors
+		}
+	})
+	sc.snap("lesson 1 mastered, 3 locked")
+	sc.press(keyEvent{kind: evDown}, keyEvent{kind: evDown}, keyEvent{kind: evEnter})
+	sc.snap("locked lesson does not start")
+	sc.press(keyEvent{kind: evUp}, keyEvent{kind: evEnter})
+	for i, line := range lessons[1].Lines {
+		if i > 0 {
+			sc.press(keyEvent{kind: evEnter})
+		}
+		sc.typeText(line)
+	}
+	sc.press(keyEvent{kind: evEnter})
+	sc.snap("lesson 2 mastered")
+	sc.press(sc.char('m'))
+	sc.snap("lesson 3 unlocked")
+	sc.finish()
+}
+
+func TestGoldenProfiles(t *testing.T) {
+	ps := newProfiles(t.TempDir())
+	for _, name := range []string{"ann", "bob"} {
+		if _, err := ps.create(name, Settings{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bob, _ := ps.open("bob")
+	bob.HighScores["Word Stack"] = 420
+	bob.save()
+	sc := newScenario(t, func(u *ui) {
+		u.profiles = ps
+		ann, _ := ps.open("ann")
+		u.useProfile(ann)
+	})
+	sc.snap("menu shows the profile")
+	sc.press(sc.char('u'), keyEvent{kind: evDown})
+	sc.snap("profile screen")
+	sc.press(sc.char('d'))
+	sc.snap("delete asks first")
+	sc.press(sc.char('n'), sc.char('n'))
+	sc.typeText("cy!")
+	sc.press(keyEvent{kind: evEnter})
+	sc.snap("bad name")
+	sc.press(keyEvent{kind: evBackspace}, keyEvent{kind: evEnter})
+	sc.snap("new profile in use")
+	sc.finish()
+}
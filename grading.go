@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Grading — named grade ladders for the score report
+// ════════════════════════════════════════════════════════════════════
+
+// Grade is one rung of a ladder: the speed and accuracy it needs.
+type Grade struct {
+	Name     string
+	CPM      float64
+	Accuracy float64
+}
+
+// Grading is a grade ladder, best grade first. A run that reaches no
+// rung gets Fail.
+type Grading struct {
+	Name   string // as given to -grading and in targets files
+	Title  string
+	Grades []Grade
+	Fail   string
+}
+
+var gradings = []Grading{
+	{
+		Name: "standard", Title: "Standard",
+		Grades: []Grade{
+			{"A+", 300, 98}, {"A", 250, 95}, {"B+", 200, 92},
+			{"B", 150, 90}, {"C", 100, 85}, {"D", 0, 80},
+		},
+		Fail: "F",
+	},
+	{
+		Name: "beginner", Title: "Beginner",
+		Grades: []Grade{
+			{"A", 150, 95}, {"B", 100, 90}, {"C", 60, 85}, {"D", 30, 75},
+		},
+		Fail: "F",
+	},
+	{
+		Name: "professional", Title: "Professional Typist",
+		Grades: []Grade{
+			{"A+", 450, 99}, {"A", 400, 98.5}, {"B", 350, 98},
+			{"C", 300, 97}, {"D", 250, 96},
+		},
+		Fail: "F",
+	},
+	{
+		// characters per minute with at most a few errors in a hundred,
+		// as Chinese typing exams pass and rank entrants
+		Name: "chinese-exam", Title: "Chinese Typing Exam",
+		Grades: []Grade{
+			{"优秀 Excellent", 100, 99}, {"良好 Good", 80, 98}, {"合格 Pass", 60, 97},
+		},
+		Fail: "不合格 Fail",
+	},
+}
+
+// defaultGrading grades lessons that do not pick a ladder; -grading
+// changes it.
+var defaultGrading = &gradings[0]
+
+func gradingByName(name string) (*Grading, error) {
+	var names []string
+	for i := range gradings {
+		if gradings[i].Name == strings.ToLower(name) {
+			return &gradings[i], nil
+		}
+		names = append(names, gradings[i].Name)
+	}
+	return nil, fmt.Errorf("unknown grading %q (choose from %s)", name, strings.Join(names, ", "))
+}
+
+// grading returns the ladder l is graded on.
+func (l *Lesson) grading() *Grading {
+	if g, err := gradingByName(l.Grading); l.Grading != "" && err == nil {
+		return g
+	}
+	return defaultGrading
+}
+
+// rung returns the index of the best grade ts reaches, len(Grades) for
+// a fail.
+func (g *Grading) rung(ts Stats) int {
+	for i, gr := range g.Grades {
+		if ts.CPM() >= gr.CPM && ts.Accuracy() >= gr.Accuracy {
+			return i
+		}
+	}
+	return len(g.Grades)
+}
+
+// grade names the grade ts earns.
+func (g *Grading) grade(ts Stats) string {
+	if i := g.rung(ts); i < len(g.Grades) {
+		return g.Grades[i].Name
+	}
+	return g.Fail
+}
+
+// nextGrade tells how far ts is from the grade above it, or that it
+// already has the top grade.
+func (g *Grading) nextGrade(ts Stats) string {
+	i := g.rung(ts)
+	if i == 0 {
+		return "Top grade — nothing left to reach"
+	}
+	next := g.Grades[i-1]
+	var need []string
+	if d := next.CPM - ts.CPM(); d > 0 {
+		need = append(need, fmt.Sprintf("+%.0f CPM", max(d, 1)))
+	}
+	if d := next.Accuracy - ts.Accuracy(); d > 0 {
+		need = append(need, fmt.Sprintf("+%.1f%% accuracy", max(d, 0.1)))
+	}
+	return fmt.Sprintf("Next grade %s: %s", next.Name, strings.Join(need, " and "))
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// statsAt builds totals typed at cpm with the given accuracy.
+func statsAt(cpm, acc float64) Stats {
+	return Stats{Total: 1000, Correct: int(acc * 10), Elapsed: time.Duration(1000 / cpm * float64(time.Minute))}
+}
+
+func TestGradingLadders(t *testing.T) {
+	cases := []struct {
+		grading  string
+		cpm, acc float64
+		grade    string
+		next     string
+	}{
+		{"standard", 320, 99, "A+", "Top grade — nothing left to reach"},
+		{"standard", 260, 93, "B+", "Next grade A: +2.0% accuracy"},
+		{"standard", 120, 70, "F", "Next grade D: +10.0% accuracy"},
+		{"beginner", 120, 92, "B", "Next grade A: +30 CPM and +3.0% accuracy"},
+		{"professional", 320, 97, "C", "Next grade B: +30 CPM and +1.0% accuracy"},
+		{"chinese-exam", 70, 98, "合格 Pass", "Next grade 良好 Good: +10 CPM"},
+		{"chinese-exam", 50, 99, "不合格 Fail", "Next grade 合格 Pass: +10 CPM"},
+	}
+	for _, c := range cases {
+		g, err := gradingByName(c.grading)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts := statsAt(c.cpm, c.acc)
+		if got := g.grade(ts); got != c.grade {
+			t.Errorf("%s %v/%v: grade %q, want %q", c.grading, c.cpm, c.acc, got, c.grade)
+		}
+		if got := g.nextGrade(ts); got != c.next {
+			t.Errorf("%s %v/%v: %q, want %q", c.grading, c.cpm, c.acc, got, c.next)
+		}
+	}
+}
+
+func TestLessonGrading(t *testing.T) {
+	if g := (&Lesson{}).grading(); g != defaultGrading {
+		t.Errorf("unset grading = %s", g.Name)
+	}
+	if g := cjkLessons[0].grading(); g.Name != "chinese-exam" {
+		t.Errorf("Chinese lesson graded %s", g.Name)
+	}
+	if _, err := gradingByName("olympic"); err == nil {
+		t.Error("unknown grading accepted")
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Grapheme clusters — what the user sees (and types) as one character
+// ════════════════════════════════════════════════════════════════════
+
+// graphemes splits s into extended grapheme clusters following the
+// UAX #29 boundary rules (all but GB9b Prepend, which no lesson needs):
+// combining marks, ZWJ emoji sequences, flag pairs, Hangul jamo and
+// Indic conjuncts stay together.
+func graphemes(s string) []string {
+	var out []string
+	start := -1
+	var prev rune
+	pict := false // cluster so far is ExtPict Extend* (GB11)
+	ris := 0      // regional indicators in the cluster (GB12/13)
+	linker := false
+	for i, r := range s {
+		if start >= 0 && graphemeBreak(prev, r, pict, ris, linker) {
+			out = append(out, s[start:i])
+			start = -1
+		}
+		if start < 0 {
+			start = i
+			pict, ris, linker = false, 0, false
+		}
+		switch {
+		case isPictographic(r):
+			pict = true
+		case !isExtend(r) && r != zwj:
+			pict = false
+		}
+		if isRegional(r) {
+			ris++
+		}
+		switch {
+		case isVirama(r):
+			linker = true
+		case !isExtend(r):
+			linker = false
+		}
+		prev = r
+	}
+	if start >= 0 {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+const zwj = 0x200D
+
+// graphemeBreak reports whether there is a cluster boundary between
+// prev and r, given the state of the cluster that prev ends.
+func graphemeBreak(prev, r rune, pict bool, ris int, linker bool) bool {
+	switch {
+	case prev == '\r' && r == '\n': // GB3
+		return false
+	case isControl(prev) || isControl(r): // GB4, GB5
+		return true
+	case hangulL(prev) && (hangulL(r) || hangulV(r) || hangulLV(r) || hangulLVT(r)): // GB6
+		return false
+	case (hangulLV(prev) || hangulV(prev)) && (hangulV(r) || hangulT(r)): // GB7
+		return false
+	case (hangulLVT(prev) || hangulT(prev)) && hangulT(r): // GB8
+		return false
+	case isExtend(r) || r == zwj: // GB9
+		return false
+	case isSpacingMark(r): // GB9a
+		return false
+	case linker && unicode.IsLetter(r) && indicScript(r): // GB9c
+		return false
+	case prev == zwj && pict && isPictographic(r): // GB11
+		return false
+	case isRegional(prev) && isRegional(r) && ris%2 == 1: // GB12, GB13
+		return false
+	}
+	return true
+}
+
+func isControl(r rune) bool {
+	return r == '\r' || r == '\n' || unicode.Is(unicode.Cc, r) ||
+		r == 0x2028 || r == 0x2029
+}
+
+// isExtend covers Grapheme_Extend plus emoji modifiers and tags.
+func isExtend(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return true
+	case r == 0x200C: // ZWNJ
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // emoji skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // emoji tag sequences
+		return true
+	}
+	return false
+}
+
+func isSpacingMark(r rune) bool {
+	return unicode.Is(unicode.Mc, r) || r == 0x0E33 || r == 0x0EB3 // Thai/Lao SARA AM
+}
+
+func isRegional(r rune) bool { return r >= 0x1F1E6 && r <= 0x1F1FF }
+
+// isVirama reports the Indic conjunct linkers (InCB=Linker).
+func isVirama(r rune) bool {
+	switch r {
+	case 0x094D, 0x09CD, 0x0ACD, 0x0B4D, 0x0C4D, 0x0D4D:
+		return true
+	}
+	return false
+}
+
+// indicScript reports whether r is in one of the scripts with InCB=Linker.
+func indicScript(r rune) bool {
+	return unicode.In(r, unicode.Devanagari, unicode.Bengali, unicode.Gujarati,
+		unicode.Oriya, unicode.Telugu, unicode.Malayalam)
+}
+
+// isPictographic approximates Extended_Pictographic.
+func isPictographic(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return !isRegional(r) && !(r >= 0x1F3FB && r <= 0x1F3FF)
+	case r >= 0x2600 && r <= 0x27BF, r >= 0x2300 && r <= 0x23FF, r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	case r >= 0x2194 && r <= 0x21AA:
+		return true
+	}
+	switch r {
+	case 0x00A9, 0x00AE, 0x203C, 0x2049, 0x2122, 0x2139, 0x3030, 0x303D, 0x3297, 0x3299:
+		return true
+	}
+	return false
+}
+
+func hangulL(r rune) bool {
+	return (r >= 0x1100 && r <= 0x115F) || (r >= 0xA960 && r <= 0xA97C)
+}
+func hangulV(r rune) bool {
+	return (r >= 0x1160 && r <= 0x11A7) || (r >= 0xD7B0 && r <= 0xD7C6)
+}
+func hangulT(r rune) bool {
+	return (r >= 0x11A8 && r <= 0x11FF) || (r >= 0xD7CB && r <= 0xD7FB)
+}
+func hangulLV(r rune) bool  { return r >= 0xAC00 && r <= 0xD7A3 && (r-0xAC00)%28 == 0 }
+func hangulLVT(r rune) bool { return r >= 0xAC00 && r <= 0xD7A3 && (r-0xAC00)%28 != 0 }
+
+// clusterWidth returns the terminal columns a grapheme cluster occupies.
+// Emoji clusters take the width of their base, widened to 2 by an emoji
+// presentation selector (VS16); flag pairs take 2; ZWJ sequences are as
+// wide as their first emoji. Everything else advances the cursor by the
+// sum of its runes, the way terminals lay out combining sequences.
+func clusterWidth(g string) int {
+	first, _ := utf8.DecodeRuneInString(g)
+	switch {
+	case isRegional(first):
+		return 2
+	case isPictographic(first):
+		if strings.ContainsRune(g, 0xFE0F) {
+			return 2
+		}
+		return runeWidth(first)
+	}
+	w := 0
+	for _, r := range g {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// lineClusters normalises a lesson line to NFC and splits it into
+// clusters, the unit Session compares input in.
+func lineClusters(line string) []string { return graphemes(norm.NFC.String(line)) }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"abc", []string{"a", "b", "c"}},
+		{"e\u0301a", []string{"e\u0301", "a"}}, // combining acute
+		{"\r\nx", []string{"\r\n", "x"}},       // CRLF stays together
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467!", []string{"\U0001F468\u200D\U0001F469\u200D\U0001F467", "!"}}, // ZWJ family
+		{"\U0001F44D\U0001F3FD", []string{"\U0001F44D\U0001F3FD"}},                                                   // skin tone modifier
+		{"\U0001F1E8\U0001F1F3\U0001F1EF\U0001F1F5", []string{"\U0001F1E8\U0001F1F3", "\U0001F1EF\U0001F1F5"}},       // flag pairs
+		{"\u2764\uFE0F", []string{"\u2764\uFE0F"}},                                                                   // emoji presentation
+		{"\u0E01\u0E33", []string{"\u0E01\u0E33"}},                                                                   // Thai SARA AM
+		{"\u0915\u094D\u0937\u093F", []string{"\u0915\u094D\u0937\u093F"}},                                           // Devanagari conjunct + matra
+		{"\u1100\u1161\u11A8", []string{"\u1100\u1161\u11A8"}},                                                       // Hangul jamo L V T
+		{"中文", []string{"中", "文"}},
+	}
+	for _, c := range cases {
+		if got := graphemes(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("graphemes(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestVLen(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int
+	}{
+		{"hello", 5},
+		{FgRed + "red" + RST, 3},
+		{"中文", 4},
+		{"ｈｉ", 4}, // fullwidth
+		{"e\u0301", 1},
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467", 2},
+		{"\U0001F1E8\U0001F1F3", 2},
+		{"\u2764\uFE0F", 2},
+		{"\u2764", 1},                   // text presentation
+		{"\u00B1", 1},                   // East Asian ambiguous counts narrow
+		{"\u0915\u094D\u0937\u093F", 3}, // as terminals advance: base, spacing consonant, spacing matra
+	}
+	for _, c := range cases {
+		if got := vLen(c.in); got != c.want {
+			t.Errorf("vLen(%q) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSessionComposedInput(t *testing.T) {
+	l := &Lesson{Name: "t", Lines: []string{"cafe\u0301"}}
+	s := newSession(l)
+	for _, r := range "cafe" {
+		s.addRune(r)
+	}
+	if !s.pending() || s.lineFinished() {
+		t.Fatal("bare 'e' towards 'é' should be pending, not finished")
+	}
+	if s.errors != 0 || s.correct != 3 {
+		t.Fatalf("while composing: correct=%d errors=%d, want 3, 0", s.correct, s.errors)
+	}
+	s.addRune('\u0301') // combining acute from a dead key
+	if !s.lineFinished() || s.errors != 0 || s.correct != 4 {
+		t.Fatalf("after accent: finished=%v correct=%d errors=%d", s.lineFinished(), s.correct, s.errors)
+	}
+}
+
+func TestSessionPendingGivenUp(t *testing.T) {
+	s := newSession(&Lesson{Name: "t", Lines: []string{"n\u00E9"}})
+	s.addRune('n')
+	s.addRune('e')
+	s.addRune('x') // not an accent: the 'e' is a plain mistake
+	if !s.lineFinished() || s.errors != 1 || s.correct != 1 {
+		t.Fatalf("finished=%v correct=%d errors=%d, want true, 1, 1", s.lineFinished(), s.correct, s.errors)
+	}
+}
+
+func TestSessionEmojiCluster(t *testing.T) {
+	s := newSession(&Lesson{Name: "t", Lines: []string{"hi \U0001F44D\U0001F3FD"}})
+	for _, r := range "hi \U0001F44D\U0001F3FD" {
+		s.addRune(r)
+	}
+	if !s.lineFinished() || s.correct != 4 || s.errors != 0 {
+		t.Fatalf("finished=%v correct=%d errors=%d, want true, 4, 0", s.lineFinished(), s.correct, s.errors)
+	}
+	s.backspace()
+	if len(s.typed) != 3 || s.correct != 3 {
+		t.Fatalf("backspace should drop the whole emoji cluster: typed=%q", s.typed)
+	}
+}
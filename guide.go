@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Finger guide — on-screen keyboard with the next key lit
+// ════════════════════════════════════════════════════════════════════
+
+// Finger guide modes.
+const (
+	guideAuto = iota // beginner lessons only
+	guideOn
+	guideOff
+)
+
+var guideNames = []string{"Auto", "On", "Off"}
+
+// guideH is the number of frame rows the guide panel takes.
+const guideH = 7
+
+func (st *Settings) toggleGuide() { st.Guide = (st.Guide + 1) % len(guideNames) }
+
+// beginnerLesson reports whether l is one of the first six lessons or a
+// generated keyboard layout lesson.
+func beginnerLesson(l *Lesson) bool {
+	for i := 0; i < 6 && i < len(lessons); i++ {
+		if l == &lessons[i] {
+			return true
+		}
+	}
+	for _, set := range layoutLessonSets {
+		for i := range set {
+			if l == &set[i] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// showGuide reports whether the typing screen draws the guide for s.
+func (st *Settings) showGuide(s *Session) bool {
+	switch st.Guide {
+	case guideOn:
+		return true
+	case guideAuto:
+		return beginnerLesson(s.lesson)
+	}
+	return false
+}
+
+// guideKeyW is the width of one key cell; guideIndent staggers the rows
+// like a real keyboard, each row prefixed by the label of its left key.
+const guideKeyW = 3
+
+var guideIndent = [4]string{"", "Tab ", "Caps ", "Shift  "}
+
+// guideRows draws keyboard l with the key for the next expected
+// cluster lit, Shift lit when it is needed, and a line naming the
+// finger to use.
+func guideRows(s *Session, l *Layout) []string {
+	next := ""
+	if len(s.typed) < len(s.target) && !s.lineFinished() {
+		next = s.target[len(s.typed)]
+	}
+	r, size := utf8.DecodeRuneInString(next)
+	row, col, shift, ok := 0, 0, false, false
+	if next != "" && size == len(next) {
+		row, col, shift, ok = l.locate(r)
+	}
+	finger := fThumb
+	if ok && row >= 0 {
+		finger = keyFinger(row, col)
+	}
+	// Shift is pressed by the hand that is not typing the key
+	leftShift := shift && finger > fThumb
+	rightShift := shift && finger < fThumb
+
+	lit := func(label string, on bool) string {
+		if on {
+			return BgGrn + FgBlk + BOLD + label + RST + TTBg + TTDim
+		}
+		return label
+	}
+
+	var rows []string
+	for i := range l.Rows {
+		var b strings.Builder
+		b.WriteString(TTDim)
+		if i == rowBottom {
+			b.WriteString(lit("Shift", leftShift) + "  ")
+		} else {
+			b.WriteString(guideIndent[i])
+		}
+		for c, k := range l.Rows[i] {
+			label := " " + string(k) + strings.Repeat(" ", guideKeyW-1-runeWidth(k))
+			b.WriteString(lit(label, ok && row == i && col == c))
+		}
+		if i == rowBottom {
+			b.WriteString(" " + lit("Shift", rightShift))
+		}
+		b.WriteString(RST + TTBg)
+		rows = append(rows, b.String())
+	}
+	space := strings.Repeat(" ", 8) + "Space" + strings.Repeat(" ", 8)
+	rows = append(rows, TTDim+strings.Repeat(" ", 13)+lit("["+space+"]", ok && row < 0)+RST+TTBg)
+
+	// centre the keyboard in the frame
+	w := 0
+	for _, r := range rows {
+		w = max(w, vLen(r))
+	}
+	pad := strings.Repeat(" ", (boxW-4-w)/2)
+	for i := range rows {
+		rows[i] = pad + rows[i]
+	}
+
+	var info string
+	switch {
+	case next == "":
+		info = "Line complete"
+	case !ok:
+		info = fmt.Sprintf("Next: %s — not on the %s keyboard", next, l.Name)
+	default:
+		key := next
+		if r == ' ' {
+			key = "Space"
+		}
+		info = fmt.Sprintf("Next: %s%s%s  →  %s%s%s", BOLD+FgGrn, key, RST+TTBg, FgYlw, fingerNames[finger], RST+TTBg)
+		if leftShift {
+			info += " + Left Shift"
+		} else if rightShift {
+			info += " + Right Shift"
+		}
+	}
+	return append([]string{""}, append(rows, info)...)
+}
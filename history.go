@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// History — finished lessons, kept on disk and exported
+// ════════════════════════════════════════════════════════════════════
+
+// LineResult is the score of one line of a finished lesson.
+type LineResult struct {
+	Line     int     `json:"line"`
+	Chars    int     `json:"chars"`
+	Correct  int     `json:"correct"`
+	Errors   int     `json:"errors"`
+	Seconds  float64 `json:"seconds"`
+	CPM      float64 `json:"cpm"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+// Result is the score of a finished lesson as stored in the history and
+// printed by -json.
+type Result struct {
+	Time     time.Time    `json:"time"`
+	Lesson   string       `json:"lesson"`
+	Lines    int          `json:"lines"`
+	Chars    int          `json:"chars"`
+	Correct  int          `json:"correct"`
+	Errors   int          `json:"errors"`
+	Seconds  float64      `json:"seconds"`
+	CPM      float64      `json:"cpm"`
+	WPM      float64      `json:"wpm"`
+	Accuracy float64      `json:"accuracy"`
+	Grade    string       `json:"grade"`
+	PerLine  []LineResult `json:"per_line,omitempty"`
+	// Misses counts the mistakes made on each target key.
+	Misses map[string]int `json:"misses,omitempty"`
+	// Pack is the digest of the homework pack the run was made for.
+	Pack string `json:"pack,omitempty"`
+}
+
+// round1 keeps one decimal, enough for speeds and percentages.
+func round1(f float64) float64 { return float64(int64(f*10+0.5)) / 10 }
+
+// newResult scores the finished session s.
+func newResult(s *Session) Result {
+	ts := s.totalStats()
+	r := Result{
+		Time:     now().UTC().Truncate(time.Second),
+		Lesson:   s.lesson.Name,
+		Lines:    len(s.lesson.Lines),
+		Chars:    ts.Total,
+		Correct:  ts.Correct,
+		Errors:   ts.Errors,
+		Seconds:  round1(ts.Elapsed.Seconds()),
+		CPM:      round1(ts.CPM()),
+		WPM:      round1(ts.WPM()),
+		Accuracy: round1(ts.Accuracy()),
+		Grade:    s.lesson.grading().grade(ts),
+		Misses:   s.misses,
+		Pack:     s.lesson.Pack,
+	}
+	for i, ls := range s.lineStats {
+		r.PerLine = append(r.PerLine, LineResult{
+			Line:     i + 1,
+			Chars:    ls.Total,
+			Correct:  ls.Correct,
+			Errors:   ls.Errors,
+			Seconds:  round1(ls.Elapsed.Seconds()),
+			CPM:      round1(ls.CPM()),
+			Accuracy: round1(ls.Accuracy()),
+		})
+	}
+	return r
+}
+
+// dataDir is where tt keeps its files.
+func dataDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".tt"
+	}
+	return filepath.Join(dir, "tt")
+}
+
+// History is a JSON-lines file of Results, oldest first. Several
+// sessions may share one, so writes are serialised.
+type History struct {
+	path string
+	mu   sync.Mutex
+}
+
+func newHistory(path string) *History { return &History{path: path} }
+
+func defaultHistoryPath() string { return filepath.Join(dataDir(), "history.jsonl") }
+
+// add appends r to the history file, creating it if needed.
+func (h *History) add(r Result) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// load reads every stored Result. A missing file is an empty history;
+// lines that do not parse are skipped.
+func (h *History) load() ([]Result, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	f, err := os.Open(h.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var rs []Result
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		var r Result
+		if json.Unmarshal(sc.Bytes(), &r) == nil {
+			rs = append(rs, r)
+		}
+	}
+	return rs, sc.Err()
+}
+
+// historyReadError is a history that could not be read. The results
+// the ui shows are then incomplete, so it outlives later saves and
+// outranks their errors.
+type historyReadError struct{ err error }
+
+func (e historyReadError) Error() string { return e.err.Error() }
+func (e historyReadError) Unwrap() error { return e.err }
+
+// loadHistory reads the ui's history into u.results, keeping any error
+// for the results and progress screens.
+func (u *ui) loadHistory() {
+	u.results, u.histErr = nil, nil
+	if u.history == nil {
+		return
+	}
+	rs, err := u.history.load()
+	u.results = rs
+	if err != nil {
+		u.histErr = historyReadError{err}
+	}
+}
+
+// histErrLine says what went wrong with the history, for a red row on
+// the results and progress screens.
+func (u *ui) histErrLine() string {
+	if errors.As(u.histErr, new(historyReadError)) {
+		return "History not read: " + u.histErr.Error()
+	}
+	return "Not saved to history: " + u.histErr.Error()
+}
+
+// record stores the result of a finished session in the history, if
+// the ui keeps one, holding on to any write error for the results
+// screen; sends it to the classroom, if there is one; and remembers it
+// for -json.
+func (u *ui) record(s *Session) {
+	r := newResult(s)
+	u.last = &r
+	u.results = append(u.results, r)
+	var err error
+	if u.history != nil {
+		err = u.history.add(r)
+	}
+	if !errors.As(u.histErr, new(historyReadError)) {
+		u.histErr = err
+	}
+	u.recordHomework()
+	if u.classroom != "" {
+		// best effort: a missing teacher must not hold up the lesson
+		go sendReport(u.classroom, classReport{Student: u.student(), Result: r})
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════
+// Export — `tt export --format csv|json`
+// ════════════════════════════════════════════════════════════════════
+
+var csvHeader = []string{"time", "lesson", "lines", "chars", "correct", "errors", "seconds", "cpm", "wpm", "accuracy", "grade"}
+
+func writeCSV(w io.Writer, rs []Result) error {
+	cw := csv.NewWriter(w)
+	cw.Write(csvHeader)
+	ff := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	for _, r := range rs {
+		cw.Write([]string{
+			r.Time.Format(time.RFC3339), r.Lesson, strconv.Itoa(r.Lines),
+			strconv.Itoa(r.Chars), strconv.Itoa(r.Correct), strconv.Itoa(r.Errors),
+			ff(r.Seconds), ff(r.CPM), ff(r.WPM), ff(r.Accuracy), r.Grade,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// exportHistory runs `tt export`; args are the words after "export".
+func exportHistory(h *History, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "csv", "output format: csv or json")
+	out := flags.String("o", "", "write to this file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown export format %q (choose csv or json)", *format)
+	}
+	rs, err := h.load()
+	if err != nil {
+		return err
+	}
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "csv" {
+		return writeCSV(w, rs)
+	}
+	if rs == nil {
+		rs = []Result{}
+	}
+	return writeJSON(w, rs)
+}
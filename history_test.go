@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func finishedSession(t *testing.T, lines ...string) *Session {
+	t.Helper()
+	s := newSession(&Lesson{Name: "t", Lines: lines})
+	for i, line := range lines {
+		for _, r := range line {
+			s.addRune(r)
+		}
+		s.finishLine()
+		if i < len(lines)-1 {
+			s.advanceLine()
+		}
+	}
+	if !s.allDone() {
+		t.Fatal("session not done")
+	}
+	return s
+}
+
+func TestHistoryRoundTrip(t *testing.T) {
+	h := newHistory(filepath.Join(t.TempDir(), "sub", "history.jsonl"))
+	if rs, err := h.load(); err != nil || rs != nil {
+		t.Fatalf("empty history: %v, %v", rs, err)
+	}
+	r := newResult(finishedSession(t, "ab", "cd"))
+	for i := 0; i < 2; i++ {
+		if err := h.add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rs, err := h.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 2 || rs[1].Lesson != "t" || len(rs[1].PerLine) != 2 || rs[1].Chars != 4 {
+		t.Fatalf("loaded %+v", rs)
+	}
+}
+
+func TestRecordShowsHistoryError(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	term := newFakeTerm()
+	u := &ui{term: term, history: newHistory(filepath.Join(blocker, "history.jsonl"))}
+	s := finishedSession(t, "ab")
+	u.record(s)
+	if u.histErr == nil || len(u.results) != 1 {
+		t.Fatalf("histErr=%v with %d results", u.histErr, len(u.results))
+	}
+	u.renderResults(s)
+	if !strings.Contains(term.output(), "Not saved to history") {
+		t.Fatal("the results screen does not say the lesson was not saved")
+	}
+}
+
+func TestUnreadHistoryIsReported(t *testing.T) {
+	term := newFakeTerm()
+	u := &ui{term: term, history: newHistory(t.TempDir())} // a directory, not a file
+	u.loadHistory()
+	if u.histErr == nil {
+		t.Fatal("reading a directory as the history gave no error")
+	}
+	u.renderProgress(nil, 0, true)
+	if !strings.Contains(term.output(), "History not read") {
+		t.Fatal("the progress screen does not say the history was not read")
+	}
+	s := finishedSession(t, "ab")
+	u.record(s)
+	u.renderResults(s)
+	if !strings.Contains(term.output(), "History not read") {
+		t.Fatal("the results screen does not say the history was not read")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	r := Result{
+		Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), Lesson: "Lesson 1, again", Lines: 2,
+		Chars: 40, Correct: 38, Errors: 2, Seconds: 12.5, CPM: 192, WPM: 38.4, Accuracy: 95, Grade: "B",
+	}
+	var b strings.Builder
+	if err := writeCSV(&b, []Result{r}); err != nil {
+		t.Fatal(err)
+	}
+	want := "time,lesson,lines,chars,correct,errors,seconds,cpm,wpm,accuracy,grade\n" +
+		"2024-03-01T12:00:00Z,\"Lesson 1, again\",2,40,38,2,12.5,192,38.4,95,B\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestExportUnknownFormatWritesNothing(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.txt")
+	err := exportHistory(newHistory(filepath.Join(t.TempDir(), "history.jsonl")), []string{"-format", "xml", "-o", out})
+	if err == nil {
+		t.Fatal("no error for -format xml")
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Fatal("the output file was created for an unknown format")
+	}
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Homework — lesson packs with signed completion records
+// ════════════════════════════════════════════════════════════════════
+
+// Pack is a homework assignment: lessons to pass and the speed and
+// accuracy each needs. The teacher's pack holds the master key; `tt
+// issue` hands each student a copy whose Key is derived from it for
+// that student alone, and their completion records are signed with it.
+// A record edited by anyone without that key fails `tt verify`. The
+// student holds it, so it cannot stop them forging their own record;
+// it keeps one student from signing records for another.
+type Pack struct {
+	Name     string       `json:"name"`
+	Key      string       `json:"key"`
+	Student  string       `json:"student,omitempty"`  // whom the pack was issued to
+	CPM      float64      `json:"cpm,omitempty"`      // target of lessons that set none
+	Accuracy float64      `json:"accuracy,omitempty"` // likewise
+	Lessons  []PackLesson `json:"lessons"`
+
+	lessons []Lesson // resolved, with their targets
+}
+
+// PackLesson names a built-in lesson or brings its own lines.
+type PackLesson struct {
+	Lesson   string   `json:"lesson,omitempty"` // a built-in lesson by name
+	Name     string   `json:"name,omitempty"`
+	Lines    []string `json:"lines,omitempty"`
+	CPM      float64  `json:"cpm,omitempty"`
+	Accuracy float64  `json:"accuracy,omitempty"`
+}
+
+// Completion is the record written once every lesson of a pack is
+// passed. HMAC signs all the other fields with the pack key.
+type Completion struct {
+	Pack      string    `json:"pack"`
+	Digest    string    `json:"digest"` // of the pack's lessons and targets
+	Student   string    `json:"student"`
+	Completed time.Time `json:"completed"`
+	Lessons   []Result  `json:"lessons"` // the best passing run of each lesson, per line
+	HMAC      string    `json:"hmac"`
+}
+
+// loadPack reads a pack and resolves its lessons.
+func loadPack(path string) (*Pack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pk Pack
+	if err := json.Unmarshal(data, &pk); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if pk.Name == "" || pk.Key == "" || len(pk.Lessons) == 0 {
+		return nil, fmt.Errorf("%s: a pack needs a name, a key and lessons", path)
+	}
+	for i, pl := range pk.Lessons {
+		l := Lesson{Name: pl.Name, Lines: pl.Lines}
+		if pl.Lesson != "" {
+			found := false
+			for _, b := range lessons {
+				if b.Name == pl.Lesson {
+					l, found = b, true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("%s: no lesson named %q", path, pl.Lesson)
+			}
+		}
+		if l.Name == "" || len(l.Lines) == 0 {
+			return nil, fmt.Errorf("%s: lesson %d needs a name and lines", path, i+1)
+		}
+		if pk.CPM > 0 || pk.Accuracy > 0 {
+			l.TargetCPM, l.TargetAcc = pk.CPM, pk.Accuracy
+		}
+		if pl.CPM > 0 {
+			l.TargetCPM = pl.CPM
+		}
+		if pl.Accuracy > 0 {
+			l.TargetAcc = pl.Accuracy
+		}
+		pk.lessons = append(pk.lessons, l)
+	}
+	sum := pk.digest()
+	for i := range pk.lessons {
+		pk.lessons[i].Pack = sum
+	}
+	return &pk, nil
+}
+
+// digest identifies what the pack asks for, so a record cannot be
+// passed off as the completion of an easier pack with the same key.
+func (pk *Pack) digest() string {
+	type item struct {
+		Name     string   `json:"name"`
+		Lines    []string `json:"lines"`
+		CPM      float64  `json:"cpm"`
+		Accuracy float64  `json:"accuracy"`
+	}
+	var items []item
+	for _, l := range pk.lessons {
+		items = append(items, item{l.Name, l.Lines, l.TargetCPM, l.TargetAcc})
+	}
+	data, _ := json.Marshal(struct {
+		Name    string `json:"name"`
+		Lessons []item `json:"lessons"`
+	}{pk.Name, items})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// passes returns the best passing run of each pack lesson in rs, and
+// whether every lesson has one. Only runs made for this version of the
+// pack count, not earlier practice of a lesson with the same name.
+func (pk *Pack) passes(rs []Result) ([]*Result, bool) {
+	best := make([]*Result, len(pk.lessons))
+	all := true
+	for i := range pk.lessons {
+		l := &pk.lessons[i]
+		for j := range rs {
+			if rs[j].Lesson == l.Name && rs[j].Pack == l.Pack && l.meets(rs[j]) && (best[i] == nil || rs[j].CPM > best[i].CPM) {
+				best[i] = &rs[j]
+			}
+		}
+		all = all && best[i] != nil
+	}
+	return best, all
+}
+
+// studentKey derives a student's signing key from the master key.
+func studentKey(master, student string) string {
+	mac := hmac.New(sha256.New, []byte(master))
+	mac.Write([]byte("tt student key\x00" + student))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signingKey is the key the records of student are signed with: the
+// key of a pack issued to them, or else one derived from the master.
+func (pk *Pack) signingKey(student string) string {
+	if pk.Student != "" {
+		return pk.Key
+	}
+	return studentKey(pk.Key, student)
+}
+
+// sign computes the HMAC of c with key over every field but HMAC.
+func (c Completion) sign(key string) string {
+	c.HMAC = ""
+	data, _ := json.Marshal(c)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// completionPath is where a student's completion record is written:
+// the working directory, named after pack and student.
+func completionPath(pack, student string) string {
+	return fmt.Sprintf("homework-%s-%s.json", cleanName(pack), cleanName(student))
+}
+
+// cleanName makes s safe in a file name.
+func cleanName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 128 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return r
+		}
+		return '-'
+	}, s)
+}
+
+// packTrack is the menu track a pack is practised from.
+func packTrack(pk *Pack) Track {
+	return Track{Name: "Homework: " + pk.Name, Lessons: pk.lessons, Pack: pk}
+}
+
+// recordHomework writes the completion record of every homework pack
+// the latest result completes, and notes where on the track menu. A
+// pack already complete before it is left alone, so the record keeps
+// the time the pack was first completed.
+func (u *ui) recordHomework() {
+	if len(u.results) == 0 {
+		return
+	}
+	for i := range tracks {
+		pk := tracks[i].Pack
+		if pk == nil {
+			continue
+		}
+		best, all := pk.passes(u.results)
+		if _, before := pk.passes(u.results[:len(u.results)-1]); !all || before {
+			continue
+		}
+		student := pk.Student
+		if student == "" {
+			student = u.student()
+		}
+		c := Completion{Pack: pk.Name, Digest: pk.digest(), Student: student, Completed: now().UTC().Truncate(time.Second)}
+		for _, r := range best {
+			c.Lessons = append(c.Lessons, *r)
+		}
+		c.HMAC = c.sign(pk.signingKey(student))
+		path := completionPath(pk.Name, c.Student)
+		f, err := os.Create(path)
+		if err == nil {
+			err = writeJSON(f, c)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+		if err != nil {
+			u.homework = FgRed + "Could not save homework: " + err.Error() + RST + TTBg
+		} else {
+			u.homework = FgGrn + BOLD + "✓ Homework complete" + RST + TTBg + " — saved to " + path
+		}
+	}
+}
+
+// packCell is menuCell for lesson i of a homework track with its
+// target, and a check once passed.
+func (u *ui) packCell(pk *Pack, i, sel int) string {
+	l := &pk.lessons[i]
+	best, _ := pk.passes(u.results)
+	cell := strings.TrimRight(menuCell(i, sel, l.Name), " ")
+	if pad := 46 - vLen(cell); pad > 0 {
+		cell += strings.Repeat(" ", pad)
+	}
+	cell += fmt.Sprintf("%s%3.0f CPM %3.0f%%%s", TTDim, l.TargetCPM, l.TargetAcc, RST+TTBg)
+	if r := best[i]; r != nil {
+		cell += fmt.Sprintf("  %s✓ %.0f CPM%s", FgGrn+BOLD, r.CPM, RST+TTBg)
+	}
+	return cell
+}
+
+// issue runs `tt issue`; args are the words after "issue". It writes
+// a copy of the teacher's pack for each student named, keyed for them,
+// next to the pack.
+func issue(args []string) error {
+	if len(args) < 2 {
+		return errors.New("usage: tt issue pack.json student...")
+	}
+	pk, err := loadPack(args[0])
+	if err != nil {
+		return err
+	}
+	if pk.Student != "" {
+		return fmt.Errorf("%s is issued to %s; issue from the teacher's pack", args[0], pk.Student)
+	}
+	for _, student := range args[1:] {
+		sp := *pk
+		sp.Student, sp.Key = student, studentKey(pk.Key, student)
+		path := strings.TrimSuffix(args[0], ".json") + "-" + cleanName(student) + ".json"
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = writeJSON(f, sp)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %s\n", student, path)
+	}
+	return nil
+}
+
+// verify runs `tt verify`; args are the words after "verify".
+func verify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	packPath := flags.String("pack", "", "the teacher's pack the record completes; checks its key, lessons and targets")
+	key := flags.String("key", "", "the teacher's master key, when the pack file is not at hand")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: tt verify [-pack pack.json | -key key] result.json")
+	}
+	var pk *Pack
+	if *packPath != "" {
+		var err error
+		if pk, err = loadPack(*packPath); err != nil {
+			return err
+		}
+		if pk.Student != "" {
+			return fmt.Errorf("%s is issued to %s; verify with the teacher's pack", *packPath, pk.Student)
+		}
+		*key = pk.Key
+	}
+	if *key == "" {
+		return errors.New("verify needs -pack or -key")
+	}
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	var c Completion
+	if err := json.Unmarshal(data, &c); err != nil {
+		return fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+	if err := c.check(studentKey(*key, c.Student), pk); err != nil {
+		return fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+	fmt.Printf("OK: %s completed %q on %s\n", c.Student, c.Pack, c.Completed.Local().Format("2006-01-02 15:04"))
+	for _, r := range c.Lessons {
+		fmt.Printf("  %-32s %5.0f CPM %5.1f%%  %d lines\n", r.Lesson, r.CPM, r.Accuracy, len(r.PerLine))
+	}
+	return nil
+}
+
+// check verifies the signature of c and, given the pack, that c is a
+// full completion of it.
+func (c Completion) check(key string, pk *Pack) error {
+	if !hmac.Equal([]byte(c.HMAC), []byte(c.sign(key))) {
+		return errors.New("signature does not match: the record was edited or signed with another key")
+	}
+	if pk == nil {
+		return nil
+	}
+	if c.Pack != pk.Name || c.Digest != pk.digest() {
+		return fmt.Errorf("record is for pack %q, not this version of %q", c.Pack, pk.Name)
+	}
+	if _, all := pk.passes(c.Lessons); !all {
+		return errors.New("not every lesson of the pack is passed")
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePack(t *testing.T, pack string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pack.json")
+	if err := os.WriteFile(path, []byte(pack), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPack(t *testing.T) {
+	pk, err := loadPack(writePack(t, `{"name": "Week 1", "key": "k", "cpm": 80, "accuracy": 90, "lessons": [
+		{"lesson": "`+lessons[0].Name+`"},
+		{"name": "Spelling", "lines": ["their there"], "cpm": 120}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := pk.lessons[0]; l.Name != lessons[0].Name || l.TargetCPM != 80 || l.TargetAcc != 90 {
+		t.Errorf("lesson 1 = %s %v %v", l.Name, l.TargetCPM, l.TargetAcc)
+	}
+	if l := pk.lessons[1]; l.TargetCPM != 120 || l.TargetAcc != 90 {
+		t.Errorf("lesson 2 targets = %v %v", l.TargetCPM, l.TargetAcc)
+	}
+	for _, bad := range []string{
+		`{"name": "x", "lessons": [{"name": "a", "lines": ["a"]}]}`,
+		`{"name": "x", "key": "k", "lessons": [{"lesson": "No Such Lesson"}]}`,
+		`{"name": "x", "key": "k", "lessons": [{"name": "a"}]}`,
+	} {
+		if _, err := loadPack(writePack(t, bad)); err == nil {
+			t.Errorf("accepted %s", bad)
+		}
+	}
+}
+
+func TestCompletionSignature(t *testing.T) {
+	pk, err := loadPack(writePack(t, `{"name": "Week 1", "key": "secret", "cpm": 60, "accuracy": 90,
+		"lessons": [{"name": "a", "lines": ["asdf"]}, {"name": "b", "lines": ["jkl;"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := pk.digest()
+	rs := []Result{
+		{Lesson: "a", CPM: 70, Accuracy: 95, PerLine: []LineResult{{Line: 1, Chars: 4, Correct: 4}}, Pack: sum},
+		{Lesson: "b", CPM: 50, Accuracy: 99, Pack: sum},
+		{Lesson: "a", CPM: 90, Accuracy: 85, Pack: sum},
+		{Lesson: "b", CPM: 200, Accuracy: 100}, // practised before the pack
+	}
+	if _, all := pk.passes(rs); all {
+		t.Fatal("b passed below its target")
+	}
+	rs = append(rs, Result{Lesson: "b", CPM: 65, Accuracy: 91, Misses: map[string]int{";": 1}, Pack: sum})
+	best, all := pk.passes(rs)
+	if !all || best[0].CPM != 70 {
+		t.Fatalf("passes = %v, %v", best, all)
+	}
+
+	c := Completion{Pack: pk.Name, Digest: pk.digest(), Student: "ann", Lessons: []Result{*best[0], *best[1]}}
+	c.HMAC = c.sign(pk.Key)
+	// the record survives a round trip through its file format
+	data, _ := json.MarshalIndent(c, "", "  ")
+	var back Completion
+	json.Unmarshal(data, &back)
+	if err := back.check(pk.Key, pk); err != nil {
+		t.Fatal(err)
+	}
+	if err := back.check("guess", nil); err == nil {
+		t.Error("wrong key accepted")
+	}
+	edited := back
+	edited.Lessons = append([]Result(nil), back.Lessons...)
+	edited.Lessons[1].CPM = 300
+	if err := edited.check(pk.Key, pk); err == nil {
+		t.Error("edited record accepted")
+	}
+	easier := *pk
+	easier.lessons = pk.lessons[:1]
+	partial := Completion{Pack: pk.Name, Digest: easier.digest(), Student: "ann", Lessons: back.Lessons[:1]}
+	partial.HMAC = partial.sign(pk.Key)
+	if err := partial.check(pk.Key, pk); err == nil {
+		t.Error("record of another pack version accepted")
+	}
+}
+
+func TestRecordHomeworkOnce(t *testing.T) {
+	pk, err := loadPack(writePack(t, `{"name": "Week 2", "key": "k", "lessons": [{"name": "a", "lines": ["asdf"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := tracks
+	tracks = append(tracks[:len(tracks):len(tracks)], packTrack(pk))
+	t.Cleanup(func() { tracks = saved })
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	u := &ui{}
+	path := completionPath(pk.Name, u.student())
+	run := func(l *Lesson) {
+		s := newSession(l)
+		for _, r := range l.Lines[0] {
+			s.addRune(r)
+		}
+		s.finishLine()
+		u.record(s)
+	}
+	run(&Lesson{Name: "a", Lines: []string{"asdf"}}) // not for the pack
+	if _, err := os.Stat(path); err == nil {
+		t.Fatal("a run outside the pack completed it")
+	}
+	run(&pk.lessons[0])
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(path)
+	run(&pk.lessons[0])
+	if _, err := os.Stat(path); err == nil {
+		t.Fatal("the record was written again after the pack was complete")
+	}
+	var c Completion
+	if err := json.Unmarshal(first, &c); err != nil || len(c.Lessons) != 1 || c.Lessons[0].Pack != pk.digest() {
+		t.Fatalf("record %+v, %v", c, err)
+	}
+}
+
+func TestIssuedPackKeys(t *testing.T) {
+	master := writePack(t, `{"name": "Week 4", "key": "master", "lessons": [{"name": "a", "lines": ["asdf"]}]}`)
+	if err := issue([]string{master, "ann", "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	teacher, _ := loadPack(master)
+	ann, err := loadPack(strings.TrimSuffix(master, ".json") + "-ann.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ann.Student != "ann" || ann.Key == teacher.Key || ann.digest() != teacher.digest() {
+		t.Fatalf("ann's pack: student %q, key %q", ann.Student, ann.Key)
+	}
+	if ann.signingKey("ann") != teacher.signingKey("ann") {
+		t.Fatal("ann's pack signs with another key than the teacher verifies with")
+	}
+
+	c := Completion{Pack: ann.Name, Digest: ann.digest(), Student: "ann",
+		Lessons: []Result{{Lesson: "a", CPM: 100, Accuracy: 100, Pack: ann.digest()}}}
+	c.HMAC = c.sign(ann.signingKey("ann"))
+	if err := c.check(studentKey("master", c.Student), teacher); err != nil {
+		t.Fatal(err)
+	}
+	// ann cannot sign a record in bob's name
+	c.Student = "bob"
+	c.HMAC = c.sign(ann.Key)
+	if err := c.check(studentKey("master", c.Student), teacher); err == nil {
+		t.Error("a record re-signed with ann's key passed as bob's")
+	}
+	if err := issue([]string{strings.TrimSuffix(master, ".json") + "-ann.json", "eve"}); err == nil {
+		t.Error("issued from a student's pack")
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Numeric keypad — 10-key drills, measured in keystrokes per hour
+// ════════════════════════════════════════════════════════════════════
+
+// Application keypad mode makes the keypad send ESC O sequences, so
+// its Enter can be told from the main one and its keys reach tt
+// whatever NumLock says. run turns it on for the whole session.
+const (
+	keypadAppMode = "\033="
+	keypadNumMode = "\033>"
+)
+
+// keypadKeys are the runes the keypad sends in application mode as the
+// final byte of ESC O; its Enter, ESC O M, is an evEnter.
+var keypadKeys = map[byte]rune{
+	'p': '0', 'q': '1', 'r': '2', 's': '3', 't': '4',
+	'u': '5', 'v': '6', 'w': '7', 'x': '8', 'y': '9',
+	'j': '*', 'k': '+', 'l': ',', 'm': '-', 'n': '.', 'o': '/', 'X': '=',
+}
+
+// KPH is the speed in keystrokes per hour, the usual 10-key measure.
+func (st Stats) KPH() float64 { return st.CPM() * 60 }
+
+// keypadLessons are the numeric keypad track: the home, top and bottom
+// rows, then zero and the decimal point, whole numbers, amounts and
+// column sums. Entries are separated by Enter, as on an adding machine.
+func keypadLessons() []Lesson {
+	entry := func(keys string, min, max int) func(*rand.Rand) string {
+		return func(rng *rand.Rand) string {
+			b := make([]byte, min+rng.Intn(max-min+1))
+			for i := range b {
+				b[i] = keys[rng.Intn(len(keys))]
+			}
+			return string(b)
+		}
+	}
+	amount := func(rng *rand.Rand) int { return 1 + rng.Intn([]int{1000, 10000, 100000}[rng.Intn(3)]) }
+	cents := func(c int) string { return fmt.Sprintf("%d.%02d", c/100, c%100) }
+	sums := func(rng *rand.Rand) string {
+		var parts []string
+		total := 0
+		for i := 2 + rng.Intn(3); i > 0; i-- {
+			c := amount(rng)
+			total += c
+			parts = append(parts, cents(c))
+		}
+		return strings.Join(parts, "+") + " " + cents(total)
+	}
+	drills := []struct {
+		name  string
+		entry func(*rand.Rand) string
+	}{
+		{"Home Row (4 5 6)", entry("456", 2, 4)},
+		{"Top Row (7 8 9)", entry("456789", 3, 4)},
+		{"Bottom Row (1 2 3)", entry("123456", 3, 4)},
+		{"Zero and Decimal Point", func(rng *rand.Rand) string {
+			return entry("1234567000", 1, 3)(rng) + "." + entry("0123456789", 1, 2)(rng)
+		}},
+		{"All Digits", entry("1234567890", 4, 6)},
+		{"Decimal Amounts", func(rng *rand.Rand) string { return cents(amount(rng)) }},
+		{"Column Sums", sums},
+	}
+	var out []Lesson
+	for _, d := range drills {
+		name := "Keypad " + d.name
+		h := fnv.New64a()
+		h.Write([]byte(name))
+		rng := rand.New(rand.NewSource(int64(h.Sum64())))
+		var lines []string
+		for len(lines) < 8 {
+			var line []string
+			for w := 0; w < 36; {
+				e := d.entry(rng)
+				line = append(line, e)
+				w += len(e) + 1
+			}
+			lines = append(lines, strings.Join(line, " "))
+		}
+		out = append(out, Lesson{Name: name, Lines: lines, Keypad: true})
+	}
+	return out
+}
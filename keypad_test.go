@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadKeyKeypad(t *testing.T) {
+	buf := make([]byte, 16)
+	for seq, want := range map[string]keyEvent{
+		"\033Op": {kind: evChar, ch: '0', text: "0", keypad: true},
+		"\033Ot": {kind: evChar, ch: '4', text: "4", keypad: true},
+		"\033On": {kind: evChar, ch: '.', text: ".", keypad: true},
+		"\033Ok": {kind: evChar, ch: '+', text: "+", keypad: true},
+		"\033OM": {kind: evEnter},
+		"\033OA": {kind: evUp},
+	} {
+		if k, err := readKey(strings.NewReader(seq), buf); err != nil || k != want {
+			t.Errorf("%q read as %+v, %v; want %+v", seq, k, err, want)
+		}
+	}
+}
+
+func TestRemapSparesKeypad(t *testing.T) {
+	st := &Settings{Layout: 1, Remap: true} // Dvorak
+	buf := make([]byte, 16)
+	for _, seq := range []string{"\033On", "\033Om", "\033Oq"} {
+		k, _ := readKey(strings.NewReader(seq), buf)
+		if got := st.remapKey(k); got.text != k.text {
+			t.Errorf("keypad %q remapped to %q", k.text, got.text)
+		}
+	}
+	if got := st.remapKey(keyEvent{kind: evChar, ch: '.', text: "."}); got.text != "v" {
+		t.Errorf("main-row . remapped to %q, want v", got.text)
+	}
+}
+
+func TestKeypadLessons(t *testing.T) {
+	for _, l := range keypadLessons() {
+		if !l.Keypad || len(l.Lines) == 0 {
+			t.Errorf("%s: keypad %v, %d lines", l.Name, l.Keypad, len(l.Lines))
+		}
+		for _, line := range l.Lines {
+			if strings.Trim(line, "0123456789.+ ") != "" || vLen(line) > genWidth {
+				t.Errorf("%s: %q is not a keypad line", l.Name, line)
+			}
+			if !strings.Contains(l.Name, "Sums") {
+				continue
+			}
+			// each sum is followed by its total
+			es := strings.Fields(line)
+			for i := 0; i+1 < len(es); i += 2 {
+				sum := 0
+				for _, a := range strings.Split(es[i], "+") {
+					sum += cents(t, a)
+				}
+				if got := cents(t, es[i+1]); got != sum {
+					t.Errorf("%s adds up to %d, not %d", es[i], sum, got)
+				}
+			}
+		}
+	}
+}
+
+func cents(t *testing.T, s string) int {
+	t.Helper()
+	n, err := strconv.Atoi(strings.Replace(s, ".", "", 1))
+	if err != nil {
+		t.Fatalf("%q is not an amount", s)
+	}
+	return n
+}
+
+func TestGoldenKeypad(t *testing.T) {
+	sc := newScenario(t)
+	// the keypad is the last item of the right column
+	sc.press(keyEvent{kind: evRight})
+	for i := 0; i < 8; i++ {
+		sc.press(keyEvent{kind: evDown})
+	}
+	sc.press(keyEvent{kind: evEnter})
+	sc.snap("track")
+	sc.press(keyEvent{kind: evEnter})
+	lines := keypadLessons()[0].Lines
+	for i, line := range lines {
+		for j, e := range strings.Fields(line) {
+			if j > 0 {
+				sc.press(keyEvent{kind: evEnter})
+			}
+			sc.typeText(e)
+		}
+		if i == 0 {
+			sc.snap("line complete")
+		}
+		sc.press(keyEvent{kind: evEnter})
+	}
+	sc.snap("results")
+	sc.finish()
+}
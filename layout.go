@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Keyboard layouts — key positions, rows and fingers
+// ════════════════════════════════════════════════════════════════════
+
+// Key rows, top to bottom.
+const (
+	rowNumber = iota
+	rowTop
+	rowHome
+	rowBottom
+)
+
+var rowNames = []string{"Number", "Top", "Home", "Bottom"}
+
+// Fingers, left to right across both hands.
+const (
+	fLeftPinky = iota
+	fLeftRing
+	fLeftMiddle
+	fLeftIndex
+	fThumb
+	fRightIndex
+	fRightMiddle
+	fRightRing
+	fRightPinky
+)
+
+var fingerNames = []string{
+	"Left pinky", "Left ring", "Left middle", "Left index", "Thumb",
+	"Right index", "Right middle", "Right ring", "Right pinky",
+}
+
+// Layout describes a keyboard by what each physical key of an ANSI
+// board types. Rows[r][c] and Shift[r][c] are the unshifted and shifted
+// characters of column c in row r, so the same index is the same key on
+// every layout. ISO-only keys are left out.
+type Layout struct {
+	Name  string
+	Rows  [4][]rune
+	Shift [4][]rune
+}
+
+func newLayout(name string, rows, shift [4]string) Layout {
+	l := Layout{Name: name}
+	for i := range rows {
+		l.Rows[i], l.Shift[i] = []rune(rows[i]), []rune(shift[i])
+	}
+	return l
+}
+
+// layouts lists the supported layouts; QWERTY comes first and is the
+// physical reference for remapping.
+var layouts = []Layout{
+	newLayout("QWERTY",
+		[4]string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"},
+		[4]string{"~!@#$%^&*()_+", "QWERTYUIOP{}|", "ASDFGHJKL:\"", "ZXCVBNM<>?"}),
+	newLayout("Dvorak",
+		[4]string{"`1234567890[]", "',.pyfgcrl/=\\", "aoeuidhtns-", ";qjkxbmwvz"},
+		[4]string{"~!@#$%^&*(){}", "\"<>PYFGCRL?+|", "AOEUIDHTNS_", ":QJKXBMWVZ"}),
+	newLayout("Colemak",
+		[4]string{"`1234567890-=", "qwfpgjluy;[]\\", "arstdhneio'", "zxcvbkm,./"},
+		[4]string{"~!@#$%^&*()_+", "QWFPGJLUY:{}|", "ARSTDHNEIO\"", "ZXCVBKM<>?"}),
+	newLayout("AZERTY",
+		[4]string{"²&é\"'(-è_çà)=", "azertyuiop^$*", "qsdfghjklmù", "wxcvbn,;:!"},
+		[4]string{"²1234567890°+", "AZERTYUIOP¨£µ", "QSDFGHJKLM%", "WXCVBN?./§"}),
+	newLayout("QWERTZ",
+		[4]string{"^1234567890ß´", "qwertzuiopü+#", "asdfghjklöä", "yxcvbnm,.-"},
+		[4]string{"°!\"§$%&/()=?`", "QWERTZUIOPÜ*'", "ASDFGHJKLÖÄ", "YXCVBNM;:_"}),
+}
+
+// layoutByName finds a layout case-insensitively.
+func layoutByName(name string) (int, error) {
+	for i := range layouts {
+		if strings.EqualFold(layouts[i].Name, name) {
+			return i, nil
+		}
+	}
+	var names []string
+	for _, l := range layouts {
+		names = append(names, strings.ToLower(l.Name))
+	}
+	return 0, fmt.Errorf("unknown layout %q (choose from %s)", name, strings.Join(names, ", "))
+}
+
+func (st *Settings) layout() *Layout { return &layouts[st.Layout] }
+
+// keyFinger returns the finger that presses column col of row in
+// standard touch typing: the index fingers take the two middle columns
+// each, the pinkies everything beyond the ring fingers.
+func keyFinger(row, col int) int {
+	if row == rowNumber {
+		// the number row starts one key further left
+		col--
+	}
+	switch {
+	case col <= 0:
+		return fLeftPinky
+	case col == 1:
+		return fLeftRing
+	case col == 2:
+		return fLeftMiddle
+	case col <= 4:
+		return fLeftIndex
+	case col <= 6:
+		return fRightIndex
+	case col == 7:
+		return fRightMiddle
+	case col == 8:
+		return fRightRing
+	}
+	return fRightPinky
+}
+
+// locate finds the key that types r: its row, column and whether Shift
+// is held. Space is reported as row -1, pressed by the thumb.
+func (l *Layout) locate(r rune) (row, col int, shift, ok bool) {
+	if r == ' ' {
+		return -1, 0, false, true
+	}
+	for row := range l.Rows {
+		for col, c := range l.Rows[row] {
+			if c == r {
+				return row, col, false, true
+			}
+		}
+		for col, c := range l.Shift[row] {
+			if c == r {
+				return row, col, true, true
+			}
+		}
+	}
+	return 0, 0, false, false
+}
+
+// remapRune translates a character typed on a physical QWERTY keyboard
+// into what the same key types on layout l.
+func (l *Layout) remapRune(r rune) rune {
+	row, col, shift, ok := layouts[0].locate(r)
+	if !ok || row < 0 || col >= len(l.Rows[row]) {
+		return r
+	}
+	if shift {
+		return l.Shift[row][col]
+	}
+	return l.Rows[row][col]
+}
+
+// remapKey applies the QWERTY-to-layout remap to a typed character when
+// Remap is on, so a layout can be learnt without switching the
+// operating system's keyboard setting.
+func (st *Settings) remapKey(k keyEvent) keyEvent {
+	if k.kind != evChar || k.keypad || !st.Remap || st.Layout == 0 {
+		return k
+	}
+	var b strings.Builder
+	for _, r := range k.text {
+		b.WriteRune(st.layout().remapRune(r))
+	}
+	k.text = b.String()
+	k.ch = []rune(k.text)[0]
+	return k
+}
+
+// ════════════════════════════════════════════════════════════════════
+// Generated row lessons
+// ════════════════════════════════════════════════════════════════════
+
+// rowLetters returns the first ten keys of a letter row: the keys under
+// the eight fingers plus the two index-finger reaches.
+func (l *Layout) rowLetters(row int) []rune { return l.Rows[row][:10] }
+
+// layoutLessonSets holds the generated lessons of every layout, in
+// layouts order.
+var layoutLessonSets = func() [][]Lesson {
+	sets := make([][]Lesson, len(layouts))
+	for i := range layouts {
+		sets[i] = layoutLessons(&layouts[i])
+	}
+	return sets
+}()
+
+// trackLessons returns the lessons of t: a layout track's are those
+// generated for the selected layout.
+func (st *Settings) trackLessons(t *Track) []Lesson {
+	if t.Layout {
+		return layoutLessonSets[st.Layout]
+	}
+	return t.Lessons
+}
+
+// layoutLessons generates home, top and bottom row lessons for l. Each
+// lesson drills the new row's keys, then practises words that use only
+// the rows learnt so far. Generation is seeded from the layout and row,
+// so a layout always gets the same lessons.
+func layoutLessons(l *Layout) []Lesson {
+	var out []Lesson
+	allowed := map[rune]bool{}
+	for _, row := range []int{rowHome, rowTop, rowBottom} {
+		keys := l.rowLetters(row)
+		for _, r := range keys {
+			allowed[r] = true
+		}
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%s/%d", l.Name, row)
+		rng := rand.New(rand.NewSource(int64(h.Sum64())))
+
+		left, right := string(keys[:4]), string(keys[6:])
+		rl, rr := []rune(left), []rune(right)
+		var lines []string
+		lines = append(lines,
+			strings.TrimSpace(strings.Repeat(left+" "+right+" ", 3)),
+			strings.TrimSpace(strings.Repeat(reverse(left)+" "+reverse(right)+" ", 3)))
+		var doubles, pairs []string
+		for i := 0; i < 4; i++ {
+			doubles = append(doubles, strings.Repeat(string(rl[i]), 2), strings.Repeat(string(rr[i]), 2))
+			pairs = append(pairs, string(rl[3-i])+string(rr[i]))
+		}
+		doubles = append(doubles, strings.Repeat(string(keys[4]), 2), strings.Repeat(string(keys[5]), 2))
+		pairs = append(pairs, string(keys[4:6]))
+		lines = append(lines, strings.Join(doubles, " "), strings.Join(append(pairs, pairs[:3]...), " "))
+
+		words := rowWords(allowed, keys)
+		for i := 0; i < 6; i++ {
+			lines = append(lines, wordLine(rng, words, keys, 34))
+		}
+		out = append(out, Lesson{
+			Name:  fmt.Sprintf("%s Row — %s", rowNames[row], l.Name),
+			Lines: lines,
+		})
+	}
+	return out
+}
+
+// rowWords picks the lesson words typeable with the allowed keys that
+// use at least one key of the newest row.
+func rowWords(allowed map[rune]bool, keys []rune) []string {
+	var words []string
+	for _, w := range lessonWords() {
+		ok, uses := true, false
+		for _, r := range w {
+			ok = ok && allowed[r]
+			uses = uses || strings.ContainsRune(string(keys), r)
+		}
+		if ok && uses {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// wordLine fills a line of about n columns with words, topping up with
+// made-up letter groups from keys when there are few real words.
+func wordLine(rng *rand.Rand, words []string, keys []rune, n int) string {
+	var letters []rune
+	for _, r := range keys {
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+	}
+	var line []string
+	for w := 0; w < n; {
+		var word string
+		if len(words) >= 8 && rng.Intn(4) > 0 {
+			word = words[rng.Intn(len(words))]
+		} else {
+			g := make([]rune, 3+rng.Intn(3))
+			for i := range g {
+				g[i] = letters[rng.Intn(len(letters))]
+			}
+			word = string(g)
+		}
+		line = append(line, word)
+		w += len([]rune(word)) + 1
+	}
+	return strings.Join(line, " ")
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// cycleLayout switches to the next layout.
+func (st *Settings) cycleLayout() { st.Layout = (st.Layout + 1) % len(layouts) }
+
+func (st *Settings) remapName() string {
+	if st.Remap {
+		return "On"
+	}
+	return "Off"
+}
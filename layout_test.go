@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLayoutsCoverAlphabet(t *testing.T) {
+	for _, l := range layouts {
+		for r := 'a'; r <= 'z'; r++ {
+			if _, _, shift, ok := l.locate(r); !ok || shift {
+				t.Errorf("%s: no unshifted key for %q", l.Name, r)
+			}
+			if _, _, shift, ok := l.locate(r - 'a' + 'A'); !ok || !shift {
+				t.Errorf("%s: no shifted key for %q", l.Name, r-'a'+'A')
+			}
+		}
+	}
+}
+
+func TestRemapRune(t *testing.T) {
+	colemak := &layouts[2]
+	for qwerty, want := range map[rune]rune{'s': 'r', 'D': 'S', 'j': 'n', ';': 'o', 'k': 'e', '1': '1', ' ': ' '} {
+		if got := colemak.remapRune(qwerty); got != want {
+			t.Errorf("remap %q = %q, want %q", qwerty, got, want)
+		}
+	}
+}
+
+func TestLayoutLessonsUseLearntRows(t *testing.T) {
+	for i := range layouts {
+		l := &layouts[i]
+		allowed := " "
+		for j, lesson := range layoutLessons(l) {
+			allowed += string(l.rowLetters([]int{rowHome, rowTop, rowBottom}[j]))
+			for _, line := range lesson.Lines {
+				for _, r := range line {
+					if !strings.ContainsRune(allowed, r) {
+						t.Errorf("%s: %q uses %q, not in %q", lesson.Name, line, r, allowed)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGuideNextKey(t *testing.T) {
+	s := newSession(&Lesson{Name: "t", Lines: []string{"Al k"}})
+	cases := []string{"Right Shift", "Right ring", "Thumb", "Right middle"}
+	for i, want := range cases {
+		rows := guideRows(s, &layouts[0])
+		if len(rows) != guideH {
+			t.Fatalf("guide has %d rows, want %d", len(rows), guideH)
+		}
+		if info := rows[len(rows)-1]; !strings.Contains(info, want) {
+			t.Errorf("key %d: %q does not name %q", i, info, want)
+		}
+		s.addRune([]rune(s.target[i])[0])
+	}
+}
@@ -1,23 +1,96 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/term"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
 )
 
 // ════════════════════════════════════════════════════════════════════
 // Terminal I/O — raw mode requires explicit \r\n
 // ════════════════════════════════════════════════════════════════════
 
-func emit(s string) {
+// Terminal is where one user's frames go and keys come from: the local
+// console, an SSH session when tt serves many users, or a fake screen in
+// tests.
+type Terminal interface {
+	Write(p []byte) (int, error)
+	Size() (w, h int, err error)
+	// Events delivers keys until the terminal is closed or its input
+	// ends; the last event is then a Ctrl-C, which every screen treats
+	// as quit.
+	Events() <-chan keyEvent
+	Close() error
+}
+
+// console is the local terminal on stdin and stdout, in raw mode until
+// it is closed.
+type console struct {
+	*keyReader
+	fd  int
+	old *term.State
+}
+
+func newConsole() (*console, error) {
+	fd := int(os.Stdin.Fd())
+	old, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enter raw mode: %w", err)
+	}
+	return &console{keyReader: newKeyReader(os.Stdin), fd: fd, old: old}, nil
+}
+
+func (*console) Write(p []byte) (int, error) { return os.Stdout.Write(p) }
+func (*console) Size() (int, int, error)     { return term.GetSize(int(os.Stdout.Fd())) }
+
+// Close stops delivering keys and restores the terminal. A read already
+// waiting on stdin cannot be interrupted and ends with the next key or
+// the process; each run of tt opens the console only once, so no key
+// meant for a later screen is lost to it.
+func (c *console) Close() error {
+	c.stop()
+	return term.Restore(c.fd, c.old)
+}
+
+// ui is one user's session: the terminal it draws on and the options
+// picked on it. Every screen draws through a ui, so that serve-ssh can
+// run many users side by side.
+type ui struct {
+	term     Terminal
+	settings Settings
+	tick     Ticker    // drives games instead of their own ticker when set
+	history  *History  // where finished lessons are stored; nil keeps none
+	histPath string    // -history, used instead of each profile's own
+	results  []Result  // finished lessons: the stored history and this run's
+	last     *Result   // the most recent finished lesson
+	profiles *Profiles // profile store; nil runs without profiles
+	profile  *Profile  // the profile in use
+	// classroom is the address of a teacher's tt classroom that
+	// finished lessons are sent to; empty sends none.
+	classroom string
+	homework  string                // news of a completed homework pack
+	histErr   error                 // why the history was not read or the last lesson not stored
+	cards     map[string]*cardState // shortcut drill progress when no profile is in use
+}
+
+func newUI(t Terminal, st Settings) *ui { return &ui{term: t, settings: st} }
+
+func (u *ui) write(s string) { io.WriteString(u.term, s) }
+
+func (u *ui) emit(s string) {
 	// Compute left margin to horizontally center boxW content
-	tw, _, _ := term.GetSize(int(os.Stdout.Fd()))
+	tw, _, _ := u.term.Size()
 	if tw <= 0 {
 		tw = 80
 	}
@@ -37,35 +110,34 @@ func emit(s string) {
 			out.WriteString(line)
 		}
 	}
-	os.Stdout.WriteString(out.String())
+	u.write(out.String())
 }
 
-func emitf(f string, a ...any) { emit(fmt.Sprintf(f, a...)) }
-func cls() {
-	os.Stdout.WriteString("\033[2J\033[H")
+func (u *ui) cls() {
+	u.write("\033[2J\033[H")
 	// Fill the entire terminal background with TT deep blue
-	w, h, err := term.GetSize(int(os.Stdout.Fd()))
+	w, h, err := u.term.Size()
 	if err != nil {
 		w, h = 80, 25
 	}
 	for i := 0; i < h; i++ {
-		os.Stdout.WriteString(fmt.Sprintf("\033[%d;1H"+TTBg+strings.Repeat(" ", w), i+1))
+		u.write(fmt.Sprintf("\033[%d;1H"+TTBg+strings.Repeat(" ", w), i+1))
 	}
 	// Vertically center: move cursor to top margin
 	topMargin := (h - 25) / 2 // approximate 25 lines of content
 	if topMargin < 0 {
 		topMargin = 0
 	}
-	os.Stdout.WriteString(fmt.Sprintf("\033[%d;1H", topMargin+1))
+	u.write(fmt.Sprintf("\033[%d;1H", topMargin+1))
 }
-func bell()    { os.Stdout.WriteString("\a") }
-func hideCur() { os.Stdout.WriteString("\033[?25l") }
-func showCur() { os.Stdout.WriteString("\033[?25h") }
+func (u *ui) bell()    { u.write("\a") }
+func (u *ui) hideCur() { u.write("\033[?25l") }
+func (u *ui) showCur() { u.write("\033[?25h") }
 
 // home repositions the cursor to the vertically-centered start
 // without clearing the screen, for flicker-free redraws.
-func home() {
-	_, h, err := term.GetSize(int(os.Stdout.Fd()))
+func (u *ui) home() {
+	_, h, err := u.term.Size()
 	if err != nil {
 		h = 25
 	}
@@ -73,7 +145,13 @@ func home() {
 	if topMargin < 0 {
 		topMargin = 0
 	}
-	os.Stdout.WriteString(fmt.Sprintf("\033[%d;1H", topMargin+1))
+	u.write(fmt.Sprintf("\033[%d;1H", topMargin+1))
+}
+
+// goodbye clears the screen on the way out.
+func (u *ui) goodbye() {
+	u.cls()
+	u.emit("Goodbye!\n")
 }
 
 // ════════════════════════════════════════════════════════════════════
@@ -96,6 +174,7 @@ const (
 	FgBlk = "\033[30m"
 
 	BgRed  = "\033[41m"
+	BgGrn  = "\033[42m"
 	BgBlu  = "\033[44m"
 	BgDkBl = "\033[48;5;17m" // Deep dark blue — classic DOS TT
 	BgCyn  = "\033[46m"
@@ -161,10 +240,37 @@ func padFrame(s string) string {
 	return strings.Join(lines, "\n") + "\n"
 }
 
+// wrapSpans splits a line of grapheme clusters into [start,end) spans
+// at most cols columns wide, breaking after a space where possible so
+// words stay whole.
+func wrapSpans(text []string, cols int) [][2]int {
+	var spans [][2]int
+	for start := 0; start < len(text); {
+		end, w := start, 0
+		for end < len(text) && (end == start || w+clusterWidth(text[end]) <= cols) {
+			w += clusterWidth(text[end])
+			end++
+		}
+		if end < len(text) {
+			for brk := end; brk > start; brk-- {
+				if text[brk-1] == " " {
+					end = brk
+					break
+				}
+			}
+		}
+		spans = append(spans, [2]int{start, end})
+		start = end
+	}
+	return spans
+}
+
 // vLen returns the visible display width of s in terminal columns,
-// correctly handling ANSI escape sequences and East Asian wide characters.
+// skipping ANSI escape sequences and measuring per grapheme cluster, so
+// combining marks, wide characters and emoji sequences all line up.
 func vLen(s string) int {
-	w, esc := 0, false
+	var vis strings.Builder
+	esc := false
 	for _, r := range s {
 		if r == '\033' {
 			esc = true
@@ -176,50 +282,36 @@ func vLen(s string) int {
 			}
 			continue
 		}
-		w += runeWidth(r)
+		vis.WriteRune(r)
+	}
+	w := 0
+	for _, g := range graphemes(vis.String()) {
+		w += clusterWidth(g)
 	}
 	return w
 }
 
-// runeWidth returns the display column width of a rune in a terminal.
+// runeWidth returns the display column width of a rune in a terminal:
+// 0 for combining marks and format characters, 2 for East Asian wide
+// and fullwidth characters, 1 otherwise.
 func runeWidth(r rune) int {
-	if isWide(r) {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case r >= 0x1160 && r <= 0x11FF: // conjoining Hangul vowels and finals
+		return 0
+	case isWide(r):
 		return 2
 	}
 	return 1
 }
 
 // isWide reports whether r is an East Asian wide character
-// that occupies 2 columns in a terminal.
+// that occupies 2 columns in a terminal, per the Unicode East Asian
+// Width property (W or F). Ambiguous characters count as narrow.
 func isWide(r rune) bool {
-	switch {
-	case r >= 0x1100 && r <= 0x115F: // Hangul Jamo
-		return true
-	case r >= 0x2E80 && r <= 0x303E: // CJK Radicals, Kangxi, Symbols
-		return true
-	case r >= 0x3041 && r <= 0x33BF: // Hiragana, Katakana, Bopomofo, Compat
-		return true
-	case r >= 0x3400 && r <= 0x4DBF: // CJK Extension A
-		return true
-	case r >= 0x4E00 && r <= 0xA4CF: // CJK Unified Ideographs, Yi
-		return true
-	case r >= 0xAC00 && r <= 0xD7AF: // Hangul Syllables
-		return true
-	case r >= 0xF900 && r <= 0xFAFF: // CJK Compatibility Ideographs
-		return true
-	case r >= 0xFE30 && r <= 0xFE6F: // CJK Compatibility Forms
-		return true
-	case r >= 0xFF01 && r <= 0xFF60: // Fullwidth Forms
-		return true
-	case r >= 0xFFE0 && r <= 0xFFE6: // Fullwidth Signs
-		return true
-	case r >= 0x20000 && r <= 0x2FA1F: // CJK Extension B+ & Compat Suppl
-		return true
-	case r >= 0x1F300 && r <= 0x1FAFF: // Emoji Symbols & Pictographs
-		return true
-	case r >= 0x2600 && r <= 0x27BF: // Misc Symbols & Dingbats
-		return true
-	case r >= 0x231A && r <= 0x23FF: // Misc Technical (some emoji)
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
 		return true
 	}
 	return false
@@ -232,11 +324,46 @@ func isWide(r rune) bool {
 type Lesson struct {
 	Name  string
 	Lines []string
+	Lang  string   // "zh" or "ja" for IME practice; "" for Latin-script lessons
+	Orig  []string // original text when Lines is its romanization
+
+	// curriculum mode: a run at or above both targets masters the lesson
+	TargetCPM float64
+	TargetAcc float64
+	Grading   string // grading ladder by name; "" for the default
+
+	Keypad bool   // numeric keypad drill: Enter ends each entry, speed is in KPH
+	Pack   string // digest of the homework pack that set the lesson, if any
+
+	next  func(st *Settings) *Lesson // generated lessons: deals a fresh lesson for each run
+	Quote *Quote                     // quote mode: the quote the lines are wrapped from
+}
+
+// Track is a themed set of lessons that opens in its own sub-menu.
+type Track struct {
+	Name    string
+	Lessons []Lesson
+	Layout  bool  // lessons are generated for the selected keyboard layout; see trackLessons
+	Pack    *Pack // homework the lessons are assigned by, if any
+
+	Generator bool // lessons deal random words; offers the generator options
+	Quotes    bool // lessons deal random quotes
+}
+
+// tracks are listed in the right column of the main menu.
+var tracks = []Track{
+	{Name: "IME Practice 中文/日本語", Lessons: cjkLessons},
+	{Name: "Keyboard Layouts", Layout: true},
+	{Name: "Word Generator", Lessons: generatorLessons(), Generator: true},
+	{Name: "Quotes", Lessons: quoteLessons(), Quotes: true},
+	{Name: "Numeric Keypad (10-key)", Lessons: keypadLessons()},
 }
 
 var lessons = []Lesson{
 	{
-		Name: "Lesson 1 — Home Row Basics",
+		Name:      "Lesson 1 — Home Row Basics",
+		TargetCPM: 60,
+		TargetAcc: 90,
 		Lines: []string{
 			"asdf jkl; asdf jkl; asdf jkl;",
 			"fdsa ;lkj fdsa ;lkj fdsa ;lkj",
@@ -253,7 +380,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 2 — Home Row Words",
+		Name:      "Lesson 2 — Home Row Words",
+		TargetCPM: 80,
+		TargetAcc: 90,
 		Lines: []string{
 			"staff shall glass flash slash",
 			"daffodil alkaloid scandal jazz",
@@ -270,7 +399,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 3 — Top Row",
+		Name:      "Lesson 3 — Top Row",
+		TargetCPM: 90,
+		TargetAcc: 90,
 		Lines: []string{
 			"qwer tyui op qwer tyui op qwer",
 			"rewq iuyt po rewq iuyt po rewq",
@@ -291,7 +422,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 4 — Bottom Row",
+		Name:      "Lesson 4 — Bottom Row",
+		TargetCPM: 90,
+		TargetAcc: 90,
 		Lines: []string{
 			"zxcv bnm, zxcv bnm, zxcv bnm,",
 			"vcxz ,mnb vcxz ,mnb vcxz ,mnb",
@@ -312,7 +445,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 5 — Full Alphabet",
+		Name:      "Lesson 5 — Full Alphabet",
+		TargetCPM: 110,
+		TargetAcc: 92,
 		Lines: []string{
 			"The quick brown fox jumps over the lazy dog.",
 			"Pack my box with five dozen liquor jugs.",
@@ -333,7 +468,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 6 — Capital Letters",
+		Name:      "Lesson 6 — Capital Letters",
+		TargetCPM: 100,
+		TargetAcc: 92,
 		Lines: []string{
 			"Alice Bob Carol Dave Eve Frank Grace",
 			"London Paris Tokyo Berlin Rome Madrid",
@@ -350,7 +487,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 7 — Numbers & Symbols",
+		Name:      "Lesson 7 — Numbers & Symbols",
+		TargetCPM: 80,
+		TargetAcc: 90,
 		Lines: []string{
 			"1234567890 1234567890 1234567890",
 			"a1b2c3d4 e5f6g7h8 i9j0 k1l2m3",
@@ -371,7 +510,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 8 — Punctuation Drill",
+		Name:      "Lesson 8 — Punctuation Drill",
+		TargetCPM: 100,
+		TargetAcc: 92,
 		Lines: []string{
 			"Hello, World! How are you? I'm fine.",
 			"\"To be, or not to be,\" he said.",
@@ -392,7 +533,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 9 — Common English",
+		Name:      "Lesson 9 — Common English",
+		TargetCPM: 150,
+		TargetAcc: 94,
 		Lines: []string{
 			"the be to of and a in that have I",
 			"it for not on with he as you do at",
@@ -413,7 +556,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 10 — Speed Building",
+		Name:      "Lesson 10 — Speed Building",
+		TargetCPM: 200,
+		TargetAcc: 95,
 		Lines: []string{
 			"the the the and and and for for for",
 			"that that with with this this from from",
@@ -434,7 +579,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 11 — Programming",
+		Name:      "Lesson 11 — Programming",
+		TargetCPM: 120,
+		TargetAcc: 92,
 		Lines: []string{
 			"func main() {",
 			`    fmt.Println("Hello, World!")`,
@@ -461,7 +608,9 @@ var lessons = []Lesson{
 		},
 	},
 	{
-		Name: "Lesson 12 — Advanced Mixed",
+		Name:      "Lesson 12 — Advanced Mixed",
+		TargetCPM: 160,
+		TargetAcc: 95,
 		Lines: []string{
 			"The CPU executes 3.5 billion cycles/second.",
 			"TCP/IP uses ports 80 (HTTP) and 443 (HTTPS).",
@@ -515,16 +664,21 @@ func (st Stats) Accuracy() float64 {
 // Typing session  (line-by-line, like classic TT)
 // ════════════════════════════════════════════════════════════════════
 
+// now is the clock sessions are timed by; tests replace it.
+var now = time.Now
+
 type Session struct {
 	lesson    *Lesson
 	lineIdx   int
-	target    []rune // current line target
-	typed     []rune // user input for current line
-	errors    int    // error count for current line
-	correct   int    // correct count for current line
-	started   bool   // first key pressed?
+	target    []string // current line target, one grapheme cluster per cell
+	typed     []string // user input for current line, per cluster
+	errors    int      // error count for current line
+	correct   int      // correct count for current line
+	settled   bool     // a half-composed last cluster has been given up on
+	started   bool     // first key pressed?
 	startTime time.Time
-	lineStats []Stats // accumulated per-line
+	lineStats []Stats        // accumulated per-line
+	misses    map[string]int // mistakes per target cluster, corrected or not
 }
 
 func newSession(l *Lesson) *Session {
@@ -535,15 +689,45 @@ func newSession(l *Lesson) *Session {
 
 func (s *Session) loadLine(idx int) {
 	s.lineIdx = idx
-	s.target = []rune(s.lesson.Lines[idx])
+	s.target = lineClusters(s.lesson.Lines[idx])
 	s.typed = nil
 	s.errors = 0
 	s.correct = 0
+	s.settled = false
 	s.started = false
 }
 
 func (s *Session) lineFinished() bool {
-	return len(s.typed) >= len(s.target)
+	return len(s.typed) >= len(s.target) && !s.pending()
+}
+
+// pending reports whether the last typed cluster is still being
+// composed: it differs from its target but is a prefix of it in
+// decomposed form, e.g. "e" typed towards "é" before the combining
+// accent arrives from a dead key or input method.
+func (s *Session) pending() bool {
+	n := len(s.typed)
+	if n == 0 || n > len(s.target) || s.settled || s.typed[n-1] == s.target[n-1] {
+		return false
+	}
+	return strings.HasPrefix(norm.NFD.String(s.target[n-1]), norm.NFD.String(s.typed[n-1]))
+}
+
+// recount recomputes the line's correct and error counts, comparing
+// input to target per grapheme cluster. A cluster still being composed
+// counts as neither.
+func (s *Session) recount() {
+	s.correct, s.errors = 0, 0
+	pending := s.pending()
+	for i, g := range s.typed {
+		switch {
+		case g == s.target[i]:
+			s.correct++
+		case pending && i == len(s.typed)-1:
+		default:
+			s.errors++
+		}
+	}
 }
 
 func (s *Session) allDone() bool {
@@ -554,42 +738,50 @@ func (s *Session) elapsed() time.Duration {
 	if !s.started {
 		return 0
 	}
-	return time.Since(s.startTime)
+	return now().Sub(s.startTime)
 }
 
-func (s *Session) addRune(r rune) {
+// addRune types r and reports whether it was a new mistake.
+func (s *Session) addRune(r rune) bool {
 	if s.lineFinished() {
-		return
+		return false
 	}
 	if !s.started {
 		s.started = true
-		s.startTime = time.Now()
+		s.startTime = now()
 	}
-	pos := len(s.typed)
-	if pos < len(s.target) {
-		if r == s.target[pos] {
-			s.correct++
-		} else {
-			s.errors++
-			bell()
+	errors := s.errors
+	n := len(s.typed)
+	switch {
+	case n > 0 && len(graphemes(s.typed[n-1]+string(r))) == 1:
+		// a combining mark, ZWJ or selector extends the cluster just typed
+		s.typed[n-1] = norm.NFC.String(s.typed[n-1] + string(r))
+	case n < len(s.target):
+		s.typed = append(s.typed, norm.NFC.String(string(r)))
+	default:
+		// the line is full but its last cluster never completed
+		s.settled = true
+	}
+	s.recount()
+	if s.errors <= errors {
+		return false
+	}
+	if i := len(s.typed) - 1; i < len(s.target) {
+		if s.misses == nil {
+			s.misses = map[string]int{}
 		}
+		s.misses[s.target[i]]++
 	}
-	s.typed = append(s.typed, r)
+	return true
 }
 
 func (s *Session) backspace() {
 	if len(s.typed) == 0 {
 		return
 	}
-	pos := len(s.typed) - 1
-	if pos < len(s.target) {
-		if s.typed[pos] == s.target[pos] {
-			s.correct--
-		} else {
-			s.errors--
-		}
-	}
-	s.typed = s.typed[:pos]
+	s.typed = s.typed[:len(s.typed)-1]
+	s.settled = false
+	s.recount()
 }
 
 func (s *Session) finishLine() Stats {
@@ -623,59 +815,265 @@ func (s *Session) totalStats() Stats {
 	return st
 }
 
+// ════════════════════════════════════════════════════════════════════
+// Settings
+// ════════════════════════════════════════════════════════════════════
+
+// View modes for the typing screen.
+const (
+	viewClassic = iota // single Target/Input pair, like DOS TT
+	viewStream         // flowing paragraph with the caret inline
+)
+
+var viewNames = []string{"Classic", "Stream"}
+
+// Settings holds the user-selectable presentation options.
+type Settings struct {
+	View     int
+	Romanize bool // IME lessons: type the pinyin/romaji instead of committing characters
+	Zhuyin   bool // IME lessons: show zhuyin instead of pinyin above Chinese text
+	Layout   int  // index into layouts
+	Remap    bool // translate physical QWERTY keys to the selected layout
+	Guide    int  // finger guide mode: guideAuto, guideOn or guideOff
+
+	Curriculum bool // lessons unlock one by one as their targets are met
+
+	// word generator: what goes into the random lines besides words
+	GenPunct   bool
+	GenCaps    bool
+	GenNumbers bool
+}
+
+// toggleView switches the typing screen between the classic and stream views.
+func (st *Settings) toggleView() { st.View = (st.View + 1) % len(viewNames) }
+
 // ════════════════════════════════════════════════════════════════════
 // Rendering
 // ════════════════════════════════════════════════════════════════════
 
-func renderMenu(sel int, first bool) {
+// menuColW is the width of one of the two menu columns.
+const menuColW = (boxW - 4) / 2
+
+// menuCell formats menu entry idx, highlighted when selected, padded
+// to the column width.
+func menuCell(idx, sel int, name string) string {
+	marker := "  "
+	color := TTFg
+	if idx == sel {
+		marker = FgCyn + "▸ " + RST + TTBg
+		color = FgCyn + BOLD
+	}
+	cell := fmt.Sprintf("%s%s%2d. %s%s", marker, color, idx+1, name, RST+TTBg)
+	if pad := menuColW - vLen(cell); pad > 0 {
+		cell += strings.Repeat(" ", pad)
+	}
+	return cell
+}
+
+// menuRows and trackRows are how many entries the main menu's columns
+// and a track menu's lesson list have room for.
+const (
+	menuRows  = boxH - 13
+	trackRows = boxH - 11
+)
+
+// menuWindow picks the entries [start, end) of n that fit in rows rows
+// with sel, an index into them, in view. When they do not all fit, one
+// row is left over for moreCell.
+func menuWindow(n, rows, sel int) (start, end int) {
+	if n <= rows {
+		return 0, n
+	}
+	shown := rows - 1
+	if sel >= shown {
+		start = min(sel-shown+1, n-shown)
+	}
+	return start, start + shown
+}
+
+// moreCell counts the entries scrolled out of view above and below.
+func moreCell(above, below int) string {
+	s := "    "
+	if above > 0 {
+		s += fmt.Sprintf("▲ %d more  ", above)
+	}
+	if below > 0 {
+		s += fmt.Sprintf("▼ %d more", below)
+	}
+	return TTDim + s + RST + TTBg
+}
+
+// menuItem is one selectable entry of the main menu: a lesson to start,
+// a game to run, the shortcut drills, or a track whose lessons open in
+// a sub-menu.
+type menuItem struct {
+	name   string
+	lesson *Lesson
+	game   *gameEntry
+	drills bool
+	track  *Track
+}
+
+// menuSection is a headed group of entries in the right menu column.
+type menuSection struct {
+	head  string
+	items []menuItem
+}
+
+func menuSections() []menuSection {
+	var gs, ts []menuItem
+	for i := range games {
+		gs = append(gs, menuItem{name: games[i].Name, game: &games[i]})
+	}
+	gs = append(gs, menuItem{name: "Shortcut Drills", drills: true})
+	for i := range tracks {
+		ts = append(ts, menuItem{name: tracks[i].Name, track: &tracks[i]})
+	}
+	return []menuSection{{"Games & Drills:", gs}, {"Tracks:", ts}}
+}
+
+// menuItems lists every selectable entry in selection order: the
+// lessons of the left column, then the right column top to bottom.
+func menuItems() []menuItem {
+	var items []menuItem
+	for i := range lessons {
+		items = append(items, menuItem{name: lessons[i].Name, lesson: &lessons[i]})
+	}
+	for _, sec := range menuSections() {
+		items = append(items, sec.items...)
+	}
+	return items
+}
+
+func (u *ui) renderMenu(sel int, first bool) {
 	if first {
-		cls()
+		u.cls()
 	} else {
-		home()
+		u.home()
 	}
-	nItems := len(lessons) + 1 // +1 for Space Invaders
 	var b strings.Builder
 	b.WriteString(hTop() + "\n")
-	b.WriteString(hBlank() + "\n")
+	if u.profile != nil {
+		who := TTDim + "U Profile: " + RST + FgCyn + BOLD + u.profile.Name + RST + TTBg
+		b.WriteString(hRow(strings.Repeat(" ", max(0, boxW-4-vLen(who)))+who) + "\n")
+	} else {
+		b.WriteString(hBlank() + "\n")
+	}
 	b.WriteString(hCenter(BOLD+TTTitle+"╔╦╗╔╦╗  Typing Tutor"+RST+TTBg) + "\n")
 	b.WriteString(hCenter(BOLD+TTTitle+" ║  ║   DOS TT Clone"+RST+TTBg) + "\n")
 	b.WriteString(hCenter(BOLD+TTTitle+" ╩  ╩   in Golang   "+RST+TTBg) + "\n")
 	b.WriteString(hBlank() + "\n")
 	b.WriteString(hCenter(TTDim+"Classic DOS TT Style Terminal Typing Practice"+RST+TTBg) + "\n")
 	b.WriteString(hMid() + "\n")
+
+	// lessons in the left column, games and tracks in the right
+	sections := menuSections()
+	header := TTTitle + "Select Lesson:" + RST + TTBg
+	header += strings.Repeat(" ", menuColW-vLen(header)) + TTTitle + sections[0].head + RST + TTBg
+	b.WriteString(hRow(header) + "\n")
+	var right []string
+	idx := len(lessons)
+	for si, sec := range sections {
+		if si > 0 {
+			right = append(right, "", TTTitle+sec.head+RST+TTBg)
+		}
+		// the last section scrolls when -pack and -corpus overfill it
+		start, end := 0, len(sec.items)
+		if si == len(sections)-1 {
+			start, end = menuWindow(len(sec.items), menuRows-len(right), sel-idx)
+		}
+		for i := start; i < end; i++ {
+			right = append(right, menuCell(idx+i, sel, sec.items[i].name))
+		}
+		if end-start < len(sec.items) {
+			right = append(right, moreCell(start, len(sec.items)-end))
+		}
+		idx += len(sec.items)
+	}
+	rows := max(len(lessons), len(right))
+	for i := 0; i < rows; i++ {
+		left := strings.Repeat(" ", menuColW)
+		if i < len(lessons) {
+			left = u.curriculumCell(i, sel)
+		}
+		r := ""
+		if i < len(right) {
+			r = right[i]
+		}
+		b.WriteString(hRow(left+r) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(fmt.Sprintf("%sV View: %s │ G Guide: %s │ C Curriculum: %s │ P Progress │ Q Quit%s",
+		TTDim, viewNames[u.settings.View], guideNames[u.settings.Guide], u.settings.curriculumName(), RST+TTBg)) + "\n")
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+// renderTrackMenu lists the lessons of track t.
+func (u *ui) renderTrackMenu(t *Track, sel int, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+t.Name+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
 	b.WriteString(hRow(TTTitle+"Select Lesson:"+RST+TTBg) + "\n")
 	b.WriteString(hBlank() + "\n")
-	for i, l := range lessons {
-		marker := "  "
-		color := TTFg
-		if i == sel {
-			marker = FgCyn + "▸ " + RST + TTBg
-			color = FgCyn + BOLD
+	ls := u.settings.trackLessons(t)
+	start, end := menuWindow(len(ls), trackRows, sel)
+	for i := start; i < end; i++ {
+		if t.Pack != nil {
+			b.WriteString(hRow(u.packCell(t.Pack, i, sel)) + "\n")
+		} else {
+			b.WriteString(hRow(menuCell(i, sel, ls[i].Name)) + "\n")
 		}
-		b.WriteString(hRow(fmt.Sprintf("%s%s%d. %s%s", marker, color, i+1, l.Name, RST+TTBg)) + "\n")
 	}
-	// Space Invaders entry
-	siIdx := len(lessons)
-	marker := "  "
-	color := TTFg
-	if sel == siIdx {
-		marker = FgCyn + "▸ " + RST + TTBg
-		color = FgCyn + BOLD
+	if end-start < len(ls) {
+		b.WriteString(hRow(moreCell(start, len(ls)-end)) + "\n")
 	}
-	b.WriteString(hRow(fmt.Sprintf("%s%s%d. Space Invaders -- Typing Game%s", marker, color, siIdx+1, RST+TTBg)) + "\n")
 	b.WriteString(hBlank() + "\n")
 	b.WriteString(hMid() + "\n")
-	b.WriteString(hRow(TTDim+"Up/Down Select │ Enter Start │ Q Quit"+RST+TTBg) + "\n")
+	if t.Pack != nil {
+		note := u.homework
+		if best, all := t.Pack.passes(u.results); !all {
+			n := 0
+			for _, r := range best {
+				if r != nil {
+					n++
+				}
+			}
+			note = fmt.Sprintf("%d of %d lessons passed — pass them all to hand in", n, len(best))
+		}
+		b.WriteString(hRow(note) + "\n")
+	}
+	if trackIsCJK(t) {
+		b.WriteString(hRow(fmt.Sprintf("%sR Type: %s │ Z Ruby: %s%s", TTDim, u.settings.imeModeName(), u.settings.rubyName(), RST+TTBg)) + "\n")
+	}
+	if t.Layout {
+		b.WriteString(hRow(fmt.Sprintf("%sL Layout: %s │ K Remap QWERTY Keys: %s%s",
+			TTDim, u.settings.layout().Name, u.settings.remapName(), RST+TTBg)) + "\n")
+	}
+	if t.Quotes {
+		b.WriteString(hRow(u.quotesNote()) + "\n")
+	}
+	if t.Generator {
+		b.WriteString(hRow(fmt.Sprintf("%sP Punctuation: %s │ C Capitals: %s │ N Numbers: %s%s",
+			TTDim, onOff(u.settings.GenPunct), onOff(u.settings.GenCaps), onOff(u.settings.GenNumbers), RST+TTBg)) + "\n")
+	}
+	b.WriteString(hRow(TTDim+"Up/Down Select │ Enter Start │ ESC Back │ Q Quit"+RST+TTBg) + "\n")
 	b.WriteString(hBot() + "\n")
-	_ = nItems
-	emit(padFrame(b.String()))
+	u.emit(padFrame(b.String()))
 }
 
-func renderTyping(s *Session, first bool) {
+func (u *ui) renderTyping(s *Session, first bool) {
 	if first {
-		cls()
+		u.cls()
 	} else {
-		home()
+		u.home()
 	}
 	el := s.elapsed()
 	mins := int(el.Minutes())
@@ -702,31 +1100,14 @@ func renderTyping(s *Session, first bool) {
 
 	// ── target line ──
 	var targetBuf strings.Builder
-	for _, r := range s.target {
-		targetBuf.WriteRune(r)
+	for _, g := range s.target {
+		targetBuf.WriteString(g)
 	}
 
 	// ── typed line with color coding ──
 	var typedBuf strings.Builder
-	for i, r := range s.typed {
-		if i < len(s.target) {
-			if r == s.target[i] {
-				typedBuf.WriteString(FgGrn)
-				typedBuf.WriteRune(s.target[i])
-				typedBuf.WriteString(RST)
-			} else {
-				// show expected char on red background
-				typedBuf.WriteString(BgRed + FgWht + BOLD)
-				typedBuf.WriteRune(s.target[i])
-				typedBuf.WriteString(RST)
-			}
-		}
-	}
-	// cursor: reverse-video on next expected char
-	if !s.lineFinished() && len(s.typed) < len(s.target) {
-		typedBuf.WriteString(REV)
-		typedBuf.WriteRune(s.target[len(s.typed)])
-		typedBuf.WriteString(RST)
+	for i := range s.target {
+		typedBuf.WriteString(inputCell(s, i))
 	}
 
 	var b strings.Builder
@@ -737,8 +1118,30 @@ func renderTyping(s *Session, first bool) {
 	b.WriteString(hRow(statLine) + "\n")
 	b.WriteString(hMid() + "\n")
 	b.WriteString(hBlank() + "\n")
-	b.WriteString(hRow(FgWht+BOLD+"Target: "+RST+TTBg+FgWht+targetBuf.String()+RST+TTBg) + "\n")
-	b.WriteString(hRow(FgWht+BOLD+"Input:  "+RST+TTBg+typedBuf.String()+RST+TTBg) + "\n")
+	guide := u.settings.showGuide(s)
+	if u.settings.View == viewStream {
+		n := streamH
+		if guide {
+			n -= 2 // leave room for the keyboard
+		}
+		for _, row := range streamRows(s, n) {
+			b.WriteString(hRow(row) + "\n")
+		}
+	} else {
+		target, typed := targetBuf.String(), typedBuf.String()
+		switch {
+		case s.lesson.Orig != nil:
+			// romanized IME lesson: the original script above the romaji
+			b.WriteString(hRow(FgWht+BOLD+"Text:   "+RST+TTBg+TTTitle+s.lesson.Orig[s.lineIdx]+RST+TTBg) + "\n")
+		case s.lesson.Lang != "":
+			// IME lesson: readings aligned above each character
+			var ruby string
+			ruby, target, typed = u.settings.rubyRows(s)
+			b.WriteString(hRow(FgWht+BOLD+"Ruby:   "+RST+TTBg+TTTitle+ruby+RST+TTBg) + "\n")
+		}
+		b.WriteString(hRow(FgWht+BOLD+"Target: "+RST+TTBg+FgWht+target+RST+TTBg) + "\n")
+		b.WriteString(hRow(FgWht+BOLD+"Input:  "+RST+TTBg+typed+RST+TTBg) + "\n")
+	}
 	b.WriteString(hBlank() + "\n")
 	// progress bar
 	progress := 0
@@ -751,14 +1154,96 @@ func renderTyping(s *Session, first bool) {
 	bar := FgGrn + strings.Repeat("█", progress) + FgGry + strings.Repeat("░", 40-progress) + RST
 	pct := float64(len(s.typed)) * 100 / float64(len(s.target))
 	b.WriteString(hRow(fmt.Sprintf("Progress: [%s] %s%.0f%%%s", bar, FgYlw, pct, RST+TTBg)) + "\n")
+	if guide {
+		for _, row := range guideRows(s, u.settings.layout()) {
+			b.WriteString(hRow(row) + "\n")
+		}
+	}
 	b.WriteString(hMid() + "\n")
-	b.WriteString(hRow(TTDim+"Backspace=Delete │ ESC=Menu │ Ctrl-C=Quit"+RST+TTBg) + "\n")
+	keys := "Backspace=Delete │ Tab=View │ ESC=Menu │ Ctrl-C=Quit"
+	if s.lesson.Keypad {
+		keys = "Enter=Next Entry │ " + keys
+	}
+	b.WriteString(hRow(TTDim+keys+RST+TTBg) + "\n")
 	b.WriteString(hBot() + "\n")
-	emit(padFrame(b.String()))
+	u.emit(padFrame(b.String()))
 }
 
-func renderLineComplete(s *Session, st Stats) {
-	cls() // only called once per line transition, no flicker
+// inputCell renders cluster i of the current line as the Input row shows
+// it: green when typed correctly, the expected cluster on red when
+// wrong, yellow while still being composed, the caret in reverse video
+// on the next expected cluster, and nothing past the caret.
+func inputCell(s *Session, i int) string {
+	switch {
+	case i < len(s.typed) && s.typed[i] == s.target[i]:
+		return FgGrn + s.target[i] + RST
+	case i == len(s.typed)-1 && s.pending():
+		// still composing: show what has been typed so far
+		return FgYlw + s.typed[i] + RST
+	case i < len(s.typed):
+		// show expected char on red background
+		return BgRed + FgWht + BOLD + s.target[i] + RST
+	case i == len(s.typed) && !s.lineFinished():
+		// cursor: reverse-video on next expected char
+		return REV + s.target[i] + RST
+	}
+	return ""
+}
+
+// streamH is the number of text rows shown by the stream view.
+const streamH = 8
+
+// streamRows lays the lesson out as a flowing paragraph: lines already
+// done are dimmed, the current line carries the typed colours and the
+// caret inline, and the upcoming lines follow. Long lines wrap. The
+// window keeps the caret on the third row once the text scrolls.
+func streamRows(s *Session, n int) []string {
+	var rows []string
+	caretRow := 0
+	for li, line := range s.lesson.Lines {
+		text := lineClusters(line)
+		for _, sp := range wrapSpans(text, boxW-4) {
+			var row strings.Builder
+			for i := sp[0]; i < sp[1]; i++ {
+				switch {
+				case li < s.lineIdx:
+					row.WriteString(TTDim + text[i])
+				case li > s.lineIdx:
+					row.WriteString(TTFg + text[i])
+				case i < len(s.typed) && s.typed[i] == text[i]:
+					row.WriteString(TTDim + text[i])
+				case i == len(s.typed)-1 && s.pending():
+					row.WriteString(FgYlw + s.typed[i] + RST + TTBg)
+				case i < len(s.typed):
+					row.WriteString(BgRed + FgWht + BOLD + text[i] + RST + TTBg)
+				case i == len(s.typed):
+					row.WriteString(REV + text[i] + RST + TTBg)
+				default:
+					row.WriteString(TTFg + BOLD + text[i] + RST + TTBg)
+				}
+			}
+			if li == s.lineIdx && len(s.typed) >= sp[0] && len(s.typed) < sp[1] {
+				caretRow = len(rows)
+			}
+			rows = append(rows, row.String()+RST+TTBg)
+		}
+	}
+	first := caretRow - 2
+	if first > len(rows)-n {
+		first = len(rows) - n
+	}
+	if first < 0 {
+		first = 0
+	}
+	rows = rows[first:]
+	for len(rows) < n {
+		rows = append(rows, "")
+	}
+	return rows[:n]
+}
+
+func (u *ui) renderLineComplete(s *Session, st Stats) {
+	u.cls() // only called once per line transition, no flicker
 	var b strings.Builder
 	b.WriteString(hTop() + "\n")
 	b.WriteString(hBlank() + "\n")
@@ -780,29 +1265,14 @@ func renderLineComplete(s *Session, st Stats) {
 		b.WriteString(hRow(TTDim+"Press any key for next line..."+RST+TTBg) + "\n")
 	}
 	b.WriteString(hBot() + "\n")
-	emit(padFrame(b.String()))
+	u.emit(padFrame(b.String()))
 }
 
-func renderResults(s *Session) {
-	cls() // only called once, no flicker
+func (u *ui) renderResults(s *Session) {
+	u.cls() // only called once, no flicker
 	ts := s.totalStats()
-
-	// grade
-	grade := "F"
-	switch {
-	case ts.Accuracy() >= 98 && ts.CPM() >= 300:
-		grade = "A+"
-	case ts.Accuracy() >= 95 && ts.CPM() >= 250:
-		grade = "A"
-	case ts.Accuracy() >= 92 && ts.CPM() >= 200:
-		grade = "B+"
-	case ts.Accuracy() >= 90 && ts.CPM() >= 150:
-		grade = "B"
-	case ts.Accuracy() >= 85 && ts.CPM() >= 100:
-		grade = "C"
-	case ts.Accuracy() >= 80:
-		grade = "D"
-	}
+	grading := s.lesson.grading()
+	grade := grading.grade(ts)
 
 	var b strings.Builder
 	b.WriteString(hTop() + "\n")
@@ -811,23 +1281,49 @@ func renderResults(s *Session) {
 	b.WriteString(hBlank() + "\n")
 	b.WriteString(hMid() + "\n")
 	b.WriteString(hRow(fmt.Sprintf("Lesson:   %s%s%s", TTFg+BOLD, s.lesson.Name, RST+TTBg)) + "\n")
-	b.WriteString(hRow(fmt.Sprintf("Lines:    %s%d%s", TTFg+BOLD, len(s.lesson.Lines), RST+TTBg)) + "\n")
+	if q := s.lesson.Quote; q != nil {
+		b.WriteString(hRow(fmt.Sprintf("By:       %s%s%s", TTFg+BOLD, truncate(q.attribution(), boxW-14), RST+TTBg)) + "\n")
+	} else {
+		b.WriteString(hRow(fmt.Sprintf("Lines:    %s%d%s", TTFg+BOLD, len(s.lesson.Lines), RST+TTBg)) + "\n")
+	}
 	b.WriteString(hBlank() + "\n")
 	b.WriteString(hRow(fmt.Sprintf("Chars:    %s%d%s", TTFg+BOLD, ts.Total, RST+TTBg)) + "\n")
 	b.WriteString(hRow(fmt.Sprintf("Correct:  %s%d%s", FgGrn+BOLD, ts.Correct, RST+TTBg)) + "\n")
 	b.WriteString(hRow(fmt.Sprintf("Errors:   %s%d%s", FgRed+BOLD, ts.Errors, RST+TTBg)) + "\n")
 	b.WriteString(hRow(fmt.Sprintf("Time:     %s%.1fs%s", FgYlw, ts.Elapsed.Seconds(), RST+TTBg)) + "\n")
-	b.WriteString(hRow(fmt.Sprintf("Speed:    %s%.0f CPM (%.0f WPM)%s", FgGrn+BOLD, ts.CPM(), ts.WPM(), RST+TTBg)) + "\n")
+	if s.lesson.Keypad {
+		b.WriteString(hRow(fmt.Sprintf("Speed:    %s%.0f KPH (%.0f CPM)%s", FgGrn+BOLD, ts.KPH(), ts.CPM(), RST+TTBg)) + "\n")
+	} else {
+		b.WriteString(hRow(fmt.Sprintf("Speed:    %s%.0f CPM (%.0f WPM)%s", FgGrn+BOLD, ts.CPM(), ts.WPM(), RST+TTBg)) + "\n")
+	}
 	b.WriteString(hRow(fmt.Sprintf("Accuracy: %s%.1f%%%s", FgCyn+BOLD, ts.Accuracy(), RST+TTBg)) + "\n")
-	b.WriteString(hBlank() + "\n")
+	if l := s.lesson; l.TargetCPM > 0 || l.TargetAcc > 0 {
+		b.WriteString(hRow(u.targetLine(l)) + "\n")
+	}
+	if s.lesson.Quote != nil {
+		b.WriteString(hRow(u.bestLine(s.lesson.Name, ts.CPM())) + "\n")
+	}
+	if u.histErr != nil {
+		b.WriteString(hRow(FgRed+truncate(u.histErrLine(), boxW-4)+RST+TTBg) + "\n")
+	} else {
+		b.WriteString(hBlank() + "\n")
+	}
 	b.WriteString(hMid() + "\n")
 	b.WriteString(hBlank() + "\n")
-	b.WriteString(hCenter(BOLD+TTTitle+"Grade: "+grade+RST+TTBg) + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"Grade: "+grade+RST+TTBg+TTDim+"  ("+grading.Title+")"+RST+TTBg) + "\n")
+	b.WriteString(hCenter(TTDim+grading.nextGrade(ts)+RST+TTBg) + "\n")
 	b.WriteString(hBlank() + "\n")
 	b.WriteString(hMid() + "\n")
-	b.WriteString(hRow(TTDim+"R=Retry │ M=Menu │ Q=Quit"+RST+TTBg) + "\n")
+	keys := "R=Retry │ M=Menu │ Q=Quit"
+	switch {
+	case s.lesson.Quote != nil:
+		keys = "R=Retry │ N=Next Quote │ M=Menu │ Q=Quit"
+	case s.lesson.next != nil:
+		keys = "R=Retry │ N=New Lines │ M=Menu │ Q=Quit"
+	}
+	b.WriteString(hRow(TTDim+keys+RST+TTBg) + "\n")
 	b.WriteString(hBot() + "\n")
-	emit(padFrame(b.String()))
+	u.emit(padFrame(b.String()))
 }
 
 // ════════════════════════════════════════════════════════════════════
@@ -837,59 +1333,163 @@ func renderResults(s *Session) {
 const (
 	siFieldW = 76 // play field width (boxW - 4)
 	siFieldH = 14 // play field height (rows aliens can occupy)
+
+	siWaveBase  = 12 // invaders in the first wave
+	siWaveGrow  = 3  // extra invaders per following wave
+	siBossEvery = 5  // every Nth wave is a boss wave
 )
 
+// siBossPhrases are carried by boss invaders; every rune must be typed
+// in order to bring the boss down.
+var siBossPhrases = []string{
+	"the quick brown fox",
+	"jumps over the lazy dog",
+	"practice makes perfect",
+	"keep your eyes on the screen",
+	"home row is where you start",
+	"accuracy first then speed",
+	"every expert was once a beginner",
+}
+
 // Invader is a single falling alien with a letter on it.
 type Invader struct {
 	ch   rune    // letter to type
+	word []rune  // boss invaders carry a whole phrase instead
+	hit  int     // runes of word already typed
 	x    int     // column position (0-based)
 	y    float64 // row position (fractional, rendered as int)
 	dead bool    // destroyed?
+
+	garbage bool // sent by the opponent in versus mode
 }
 
-// SpaceGame holds the full game state.
+func (inv *Invader) boss() bool { return inv.word != nil }
+
+// Ticker delivers the game clock. The real one wraps time.Ticker;
+// tests and replays step the game by hand instead.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type wallTicker struct{ t *time.Ticker }
+
+func (w wallTicker) C() <-chan time.Time { return w.t.C }
+func (w wallTicker) Stop()               { w.t.Stop() }
+
+func newWallTicker(d time.Duration) Ticker { return wallTicker{time.NewTicker(d)} }
+
+// SpaceGame holds the full game state. All randomness comes from rng,
+// so two games built with the same seed see the same alien stream.
 type SpaceGame struct {
-	invaders   []Invader
-	score      int
-	lives      int
-	level      int
-	missed     int
-	hits       int
-	speed      float64 // rows per tick
-	spawnRate  int     // ticks between spawns
-	tick       int
-	lastRender time.Time
-	tickRate   time.Duration
-	gameOver   bool
-}
-
-func newSpaceGame() *SpaceGame {
+	seed      int64
+	rng       *rand.Rand
+	invaders  []Invader
+	score     int
+	lives     int
+	level     int // current wave number
+	missed    int
+	hits      int
+	speed     float64 // rows per tick
+	spawnRate int     // ticks between spawns
+	tick      int
+	tickRate  time.Duration
+	gameOver  bool
+
+	paused       bool // pause overlay shown, world frozen
+	intermission bool // wave cleared, waiting for the player
+
+	// versus mode: garbage comes from its own rng so that receiving it
+	// leaves the shared alien stream in step with the opponent's
+	versus *vsOpponent
+	grng   *rand.Rand
+	kills  int // regular aliens destroyed, towards the next garbage
+	outbox int // garbage earned and not yet sent
+
+	// per-wave bookkeeping
+	waveSize   int // invaders to spawn this wave
+	spawned    int // invaders spawned so far this wave
+	waveHits   int
+	waveMissed int
+	waveKeys   int // keystrokes typed this wave
+	waveGood   int // keystrokes that hit something
+	waveBonus  int // bonus awarded when the wave was cleared
+}
+
+func newSpaceGame(seed int64) *SpaceGame {
 	g := &SpaceGame{
-		lives:     3,
-		level:     1,
-		speed:     0.3,
-		spawnRate: 8,
-		tickRate:  120 * time.Millisecond,
+		seed:     seed,
+		rng:      rand.New(rand.NewSource(seed)),
+		grng:     rand.New(rand.NewSource(^seed)),
+		lives:    3,
+		tickRate: 120 * time.Millisecond,
 	}
+	g.startWave(1)
 	return g
 }
 
+// won reports whether a versus game ended with the opponent out first.
+func (g *SpaceGame) won() bool {
+	return g.versus != nil && !g.gameOver && (g.versus.Over || g.versus.Left)
+}
+
+// bossWave reports whether wave n is a boss wave.
+func bossWave(n int) bool { return n%siBossEvery == 0 }
+
+// startWave resets the per-wave counters and difficulty for wave n.
+func (g *SpaceGame) startWave(n int) {
+	g.level = n
+	g.intermission = false
+	g.speed = 0.3 + 0.08*float64(n-1)
+	g.spawnRate = 8 - (n - 1)
+	if g.spawnRate < 3 {
+		g.spawnRate = 3
+	}
+	g.waveSize = siWaveBase + siWaveGrow*(n-1)
+	if bossWave(n) {
+		g.waveSize = 1
+	}
+	g.spawned = 0
+	g.waveHits, g.waveMissed = 0, 0
+	g.waveKeys, g.waveGood = 0, 0
+	g.waveBonus = 0
+	g.tick = 0
+}
+
 func (g *SpaceGame) spawnInvader() {
+	g.spawned++
+	if bossWave(g.level) {
+		word := []rune(siBossPhrases[g.rng.Intn(len(siBossPhrases))])
+		x := g.rng.Intn(siFieldW-len(word)-2) + 1
+		g.invaders = append(g.invaders, Invader{word: word, x: x, y: 0})
+		return
+	}
 	// pick a random lowercase letter
 	letters := "abcdefghijklmnopqrstuvwxyz"
-	ch := rune(letters[rand.Intn(len(letters))])
-	x := rand.Intn(siFieldW-4) + 2
+	ch := rune(letters[g.rng.Intn(len(letters))])
+	x := g.rng.Intn(siFieldW-4) + 2
 	g.invaders = append(g.invaders, Invader{ch: ch, x: x, y: 0})
 }
 
+// addGarbage drops n garbage invaders from the opponent onto the top
+// row. They fall and cost lives like any alien, but earn no garbage.
+func (g *SpaceGame) addGarbage(n int) {
+	letters := "abcdefghijklmnopqrstuvwxyz"
+	for i := 0; i < n; i++ {
+		ch := rune(letters[g.grng.Intn(len(letters))])
+		x := g.grng.Intn(siFieldW-4) + 2
+		g.invaders = append(g.invaders, Invader{ch: ch, x: x, y: 0, garbage: true})
+	}
+}
+
 func (g *SpaceGame) update() {
-	if g.gameOver {
+	if g.gameOver || g.paused || g.intermission || g.won() {
 		return
 	}
 	g.tick++
 
-	// spawn new invaders
-	if g.tick%g.spawnRate == 0 {
+	// spawn new invaders until the wave is exhausted
+	if g.spawned < g.waveSize && (g.spawned == 0 || g.tick%g.spawnRate == 0) {
 		g.spawnInvader()
 	}
 
@@ -900,12 +1500,18 @@ func (g *SpaceGame) update() {
 		if inv.dead {
 			continue
 		}
-		inv.y += g.speed
+		if inv.boss() {
+			inv.y += g.speed / 4 // bosses are slow but long
+		} else {
+			inv.y += g.speed
+		}
 		if int(inv.y) >= siFieldH {
 			// reached bottom — lost a life
-			g.lives--
+			if g.lives > 0 {
+				g.lives--
+			}
 			g.missed++
-			bell()
+			g.waveMissed++
 			if g.lives <= 0 {
 				g.gameOver = true
 			}
@@ -915,23 +1521,47 @@ func (g *SpaceGame) update() {
 	}
 	g.invaders = alive
 
-	// level up every 15 hits
-	newLevel := g.hits/15 + 1
-	if newLevel > g.level {
-		g.level = newLevel
-		g.speed += 0.08
-		if g.spawnRate > 3 {
-			g.spawnRate--
-		}
+	if !g.gameOver && g.spawned >= g.waveSize && len(g.invaders) == 0 {
+		g.endWave()
 	}
 }
 
+// endWave awards the clear bonus and enters the intermission screen.
+func (g *SpaceGame) endWave() {
+	g.waveBonus = 50 * g.level
+	if g.waveMissed == 0 {
+		g.waveBonus *= 2 // perfect wave
+	}
+	g.score += g.waveBonus
+	g.intermission = true
+}
+
 func (g *SpaceGame) tryShoot(ch rune) bool {
+	g.waveKeys++
+
+	// a boss absorbs keystrokes that match its next rune
+	for i := range g.invaders {
+		inv := &g.invaders[i]
+		if inv.dead || !inv.boss() || inv.word[inv.hit] != ch {
+			continue
+		}
+		inv.hit++
+		g.waveGood++
+		if inv.hit == len(inv.word) {
+			inv.dead = true
+			g.score += 20 * len(inv.word) * g.level
+			g.hits++
+			g.waveHits++
+			g.outbox += siGarbageBoss
+		}
+		return true
+	}
+
 	// find the lowest (closest to bottom) invader with this letter
 	bestIdx := -1
 	bestY := -1.0
 	for i, inv := range g.invaders {
-		if !inv.dead && inv.ch == ch {
+		if !inv.dead && !inv.boss() && inv.ch == ch {
 			if inv.y > bestY {
 				bestY = inv.y
 				bestIdx = i
@@ -940,18 +1570,94 @@ func (g *SpaceGame) tryShoot(ch rune) bool {
 	}
 	if bestIdx >= 0 {
 		g.invaders[bestIdx].dead = true
+		if !g.invaders[bestIdx].garbage {
+			if g.kills++; g.kills%siGarbagePer == 0 {
+				g.outbox++
+			}
+		}
 		g.score += 10 * g.level
 		g.hits++
+		g.waveHits++
+		g.waveGood++
 		return true
 	}
 	return false
 }
 
-func renderSpaceGame(g *SpaceGame, firstFrame bool) {
+// waveAccuracy is the share of this wave's keystrokes that hit an alien.
+func (g *SpaceGame) waveAccuracy() float64 {
+	if g.waveKeys == 0 {
+		return 100
+	}
+	return float64(g.waveGood) * 100 / float64(g.waveKeys)
+}
+
+// siOverlay returns the lines drawn over the play field while the game
+// is paused or between waves, or nil when the field is visible.
+func (g *SpaceGame) siOverlay() []string {
+	switch {
+	case g.gameOver:
+		return nil
+	case g.won():
+		why := g.versus.Name + " is out of lives"
+		if g.versus.Left {
+			why = g.versus.Name + " left the game"
+		}
+		return []string{
+			BOLD + TTHilite + "*** YOU WIN! ***" + RST + TTBg,
+			"",
+			why,
+			fmt.Sprintf("Wave %d   Score %d", g.level, g.score),
+			"",
+			TTDim + "ESC/Q=Quit" + RST + TTBg,
+		}
+	case g.paused:
+		return []string{
+			BOLD + TTTitle + "-- PAUSED --" + RST + TTBg,
+			"",
+			fmt.Sprintf("Wave %d   Score %d", g.level, g.score),
+			"",
+			TTDim + "ESC/Enter=Resume │ M=Menu │ Q=Quit" + RST + TTBg,
+		}
+	case g.intermission:
+		next := fmt.Sprintf("Next: Wave %d", g.level+1)
+		if bossWave(g.level + 1) {
+			next = BOLD + FgRed + fmt.Sprintf("Next: Wave %d -- BOSS WAVE!", g.level+1) + RST + TTBg
+		}
+		return []string{
+			BOLD + TTHilite + fmt.Sprintf("Wave %d cleared!", g.level) + RST + TTBg,
+			"",
+			fmt.Sprintf("Hits: %s%d/%d%s   Missed: %s%d%s   Accuracy: %s%.1f%%%s",
+				FgGrn+BOLD, g.waveHits, g.waveSize, RST+TTBg,
+				FgRed+BOLD, g.waveMissed, RST+TTBg,
+				FgCyn+BOLD, g.waveAccuracy(), RST+TTBg),
+			fmt.Sprintf("Wave bonus: %s+%d%s   Score: %s%d%s",
+				FgYlw+BOLD, g.waveBonus, RST+TTBg,
+				FgYlw+BOLD, g.score, RST+TTBg),
+			"",
+			next,
+			"",
+			TTDim + "Enter=Next Wave │ M=Menu │ Q=Quit" + RST + TTBg,
+		}
+	}
+	return nil
+}
+
+// siCenter centres s within the play field width.
+func siCenter(s string) string {
+	l := vLen(s)
+	if l >= siFieldW {
+		return s
+	}
+	left := (siFieldW - l) / 2
+	return strings.Repeat(" ", left) + s + TTFg + strings.Repeat(" ", siFieldW-l-left)
+}
+
+func (u *ui) renderSpaceGame(g *SpaceGame, firstFrame bool) {
 	if firstFrame {
-		cls()
+		u.cls()
 	} else {
-		home()
+		u.home()
 	}
 	var b strings.Builder
 
@@ -961,13 +1667,21 @@ func renderSpaceGame(g *SpaceGame, firstFrame bool) {
 
 	// Status bar
 	livesStr := strings.Repeat("* ", g.lives) + strings.Repeat("  ", 3-g.lives)
+	waveStr := fmt.Sprintf("%d", g.level)
+	if bossWave(g.level) {
+		waveStr += " BOSS"
+	}
 	b.WriteString(hRow(fmt.Sprintf(
-		"Score:%s%5d%s  Level:%s%d%s  Lives:%s%s%s  Hits:%s%d%s",
+		"Score:%s%5d%s  Wave:%s%s%s  Lives:%s%s%s  Hits:%s%d%s  Left:%s%d%s",
 		FgYlw+BOLD, g.score, RST+TTBg,
-		FgCyn+BOLD, g.level, RST+TTBg,
+		FgCyn+BOLD, waveStr, RST+TTBg,
 		FgRed+BOLD, livesStr, RST+TTBg,
 		FgGrn+BOLD, g.hits, RST+TTBg,
+		TTFg+BOLD, g.waveSize-g.spawned+len(g.invaders), RST+TTBg,
 	)) + "\n")
+	if g.versus != nil {
+		b.WriteString(hRow(vsPanel(g.versus)) + "\n")
+	}
 	b.WriteString(hMid() + "\n")
 
 	// Build the play field
@@ -978,6 +1692,17 @@ func renderSpaceGame(g *SpaceGame, firstFrame bool) {
 			field[r][c] = ' '
 		}
 	}
+	// typed marks boss runes that have already been shot away
+	typed := make([][]bool, siFieldH)
+	for r := range typed {
+		typed[r] = make([]bool, siFieldW)
+	}
+
+	// garbage marks invaders sent by the opponent
+	garbage := make([][]bool, siFieldH)
+	for r := range garbage {
+		garbage[r] = make([]bool, siFieldW)
+	}
 
 	// Place invaders on the field
 	for _, inv := range g.invaders {
@@ -985,17 +1710,35 @@ func renderSpaceGame(g *SpaceGame, firstFrame bool) {
 			continue
 		}
 		row := int(inv.y)
-		if row >= 0 && row < siFieldH && inv.x >= 0 && inv.x < siFieldW {
+		if row < 0 || row >= siFieldH {
+			continue
+		}
+		if inv.boss() {
+			for i, r := range inv.word {
+				if c := inv.x + i; c >= 0 && c < siFieldW {
+					field[row][c] = r
+					typed[row][c] = i < inv.hit
+				}
+			}
+			continue
+		}
+		if inv.x >= 0 && inv.x < siFieldW {
 			field[row][inv.x] = inv.ch
+			garbage[row][inv.x] = inv.garbage
 		}
 	}
 
 	// Render field rows
+	rows := make([]string, siFieldH)
 	for r := 0; r < siFieldH; r++ {
 		var row strings.Builder
 		for c := 0; c < siFieldW; c++ {
 			ch := field[r][c]
-			if ch != ' ' {
+			if typed[r][c] {
+				row.WriteString(TTDim + string(ch) + RST + TTBg)
+			} else if garbage[r][c] {
+				row.WriteString(BgGry + FgWht + BOLD + string(ch) + RST + TTBg)
+			} else if ch != ' ' {
 				// Color aliens by proximity: green at top, yellow mid, red near bottom
 				color := FgGrn
 				if r > siFieldH*2/3 {
@@ -1020,18 +1763,30 @@ func renderSpaceGame(g *SpaceGame, firstFrame bool) {
 				}
 			}
 		}
-		// Pad to fit inside the box (boxW-4 inner width, field is siFieldW)
-		padLeft := (boxW - 4 - siFieldW) / 2
-		padRight := boxW - 4 - siFieldW - padLeft
-		if padLeft < 0 {
-			padLeft = 0
-		}
-		if padRight < 0 {
-			padRight = 0
+		rows[r] = row.String()
+	}
+
+	// Pause / intermission overlay replaces the middle of the field
+	if ov := g.siOverlay(); ov != nil {
+		top := (siFieldH - len(ov)) / 2
+		for i, line := range ov {
+			rows[top+i] = siCenter(line)
 		}
+	}
+
+	// Pad to fit inside the box (boxW-4 inner width, field is siFieldW)
+	padLeft := (boxW - 4 - siFieldW) / 2
+	padRight := boxW - 4 - siFieldW - padLeft
+	if padLeft < 0 {
+		padLeft = 0
+	}
+	if padRight < 0 {
+		padRight = 0
+	}
+	for _, row := range rows {
 		b.WriteString(TTBg + TTBorder + "║ " + TTFg +
 			strings.Repeat(" ", padLeft) +
-			row.String() +
+			row +
 			strings.Repeat(" ", padRight) +
 			TTBorder + " ║" + RST + "\n")
 	}
@@ -1049,17 +1804,30 @@ func renderSpaceGame(g *SpaceGame, firstFrame bool) {
 
 	b.WriteString(hMid() + "\n")
 	if g.gameOver {
-		b.WriteString(hCenter(BOLD+FgRed+"GAME OVER!"+RST+TTBg) + "\n")
-		b.WriteString(hRow(fmt.Sprintf("Final Score: %s%d%s   Hits: %s%d%s   Missed: %s%d%s",
+		final := fmt.Sprintf("Final Score: %s%d%s   Wave: %s%d%s   Hits: %s%d%s   Missed: %s%d%s",
 			FgYlw+BOLD, g.score, RST+TTBg,
+			FgCyn+BOLD, g.level, RST+TTBg,
 			FgGrn+BOLD, g.hits, RST+TTBg,
-			FgRed+BOLD, g.missed, RST+TTBg)) + "\n")
-		b.WriteString(hRow(TTDim+"R=Restart │ M=Menu │ Q=Quit"+RST+TTBg) + "\n")
+			FgRed+BOLD, g.missed, RST+TTBg)
+		if g.versus != nil {
+			// the opponent's panel took a row above, so this takes one less
+			b.WriteString(hCenter(fmt.Sprintf("%sGAME OVER!%s  %sYou lose — %s outlasted you%s",
+				BOLD+FgRed, RST+TTBg, TTDim, g.versus.Name, RST+TTBg)) + "\n")
+			b.WriteString(hRow(final+TTDim+"   ESC/Q=Quit"+RST+TTBg) + "\n")
+		} else {
+			b.WriteString(hCenter(BOLD+FgRed+"GAME OVER!"+RST+TTBg) + "\n")
+			b.WriteString(hRow(final) + "\n")
+			b.WriteString(hRow(fmt.Sprintf("%sSeed: %d │ R=Restart (same seed) │ M=Menu │ Q=Quit%s", TTDim, g.seed, RST+TTBg)) + "\n")
+		}
+	} else if g.won() {
+		b.WriteString(hRow(TTDim+"You win │ ESC/Q=Quit"+RST+TTBg) + "\n")
+	} else if g.versus != nil {
+		b.WriteString(hRow(TTDim+"Type letters to shoot aliens │ Grey aliens are garbage │ ESC=Forfeit"+RST+TTBg) + "\n")
 	} else {
-		b.WriteString(hRow(TTDim+"Type letters to shoot aliens │ ESC=Menu"+RST+TTBg) + "\n")
+		b.WriteString(hRow(TTDim+"Type letters to shoot aliens │ ESC=Pause"+RST+TTBg) + "\n")
 	}
 	b.WriteString(hBot() + "\n")
-	emit(padFrame(b.String()))
+	u.emit(padFrame(b.String()))
 }
 
 // ════════════════════════════════════════════════════════════════════
@@ -1074,49 +1842,98 @@ const (
 	evEscape
 	evUp
 	evDown
+	evLeft
+	evRight
+	evTab
 	evCtrlC
+	evCtrl // a Ctrl chord the keys above do not cover; ch is its letter
+	evAlt  // Alt (Meta) with a key, sent as ESC and the key; ch is the key
 )
 
 type keyEvent struct {
 	kind int
-	ch   rune
+	ch   rune   // first rune of an evChar; the key of an evCtrl or evAlt
+	text string // every rune of an evChar: IME commits and dead-key
+	// compositions can deliver several runes in one read
+	keypad bool // an evChar from the numeric keypad, which -remap leaves alone
 }
 
-func readKey(buf []byte) keyEvent {
-	n, err := os.Stdin.Read(buf)
+// readKey reads one key from in. A read error means the terminal is
+// gone and is returned so the caller can stop reading.
+func readKey(in io.Reader, buf []byte) (keyEvent, error) {
+	n, err := in.Read(buf)
 	if err != nil || n == 0 {
-		return keyEvent{kind: evNone}
+		return keyEvent{kind: evNone}, err
 	}
 	switch {
 	case n == 1 && buf[0] == 3:
-		return keyEvent{kind: evCtrlC}
+		return keyEvent{kind: evCtrlC}, nil
 	case n == 1 && buf[0] == 27:
-		return keyEvent{kind: evEscape}
+		return keyEvent{kind: evEscape}, nil
 	case n == 1 && (buf[0] == 127 || buf[0] == 8):
-		return keyEvent{kind: evBackspace}
+		return keyEvent{kind: evBackspace}, nil
+	case n == 1 && buf[0] == '\t':
+		return keyEvent{kind: evTab}, nil
 	case n == 1 && (buf[0] == '\r' || buf[0] == '\n'):
-		return keyEvent{kind: evEnter}
+		return keyEvent{kind: evEnter}, nil
 	case n == 3 && buf[0] == 27 && buf[1] == '[':
 		switch buf[2] {
 		case 'A':
-			return keyEvent{kind: evUp}
+			return keyEvent{kind: evUp}, nil
+		case 'B':
+			return keyEvent{kind: evDown}, nil
+		case 'C':
+			return keyEvent{kind: evRight}, nil
+		case 'D':
+			return keyEvent{kind: evLeft}, nil
+		}
+		return keyEvent{kind: evNone}, nil
+	case n == 3 && buf[0] == 27 && buf[1] == 'O':
+		// application keypad and cursor modes
+		if r, ok := keypadKeys[buf[2]]; ok {
+			return keyEvent{kind: evChar, ch: r, text: string(r), keypad: true}, nil
+		}
+		switch buf[2] {
+		case 'M':
+			return keyEvent{kind: evEnter}, nil
+		case 'A':
+			return keyEvent{kind: evUp}, nil
 		case 'B':
-			return keyEvent{kind: evDown}
+			return keyEvent{kind: evDown}, nil
+		case 'C':
+			return keyEvent{kind: evRight}, nil
+		case 'D':
+			return keyEvent{kind: evLeft}, nil
+		}
+		return keyEvent{kind: evNone}, nil
+	case n == 1 && buf[0] < 32:
+		switch b := buf[0]; {
+		case b == 0:
+			return keyEvent{kind: evCtrl, ch: ' '}, nil
+		case b <= 26:
+			return keyEvent{kind: evCtrl, ch: rune('a' + b - 1)}, nil
+		case b >= 28:
+			return keyEvent{kind: evCtrl, ch: rune(`\]^_`[b-28])}, nil
 		}
-		return keyEvent{kind: evNone}
+	case n == 2 && buf[0] == 27 && buf[1] >= 32 && buf[1] < 127:
+		return keyEvent{kind: evAlt, ch: rune(buf[1])}, nil
 	case n >= 1 && buf[0] >= 32:
-		// decode single UTF-8 rune
-		r := rune(buf[0])
-		if n >= 2 && buf[0]&0xE0 == 0xC0 {
-			r = rune(buf[0]&0x1F)<<6 | rune(buf[1]&0x3F)
-		} else if n >= 3 && buf[0]&0xF0 == 0xE0 {
-			r = rune(buf[0]&0x0F)<<12 | rune(buf[1]&0x3F)<<6 | rune(buf[2]&0x3F)
-		} else if n >= 4 && buf[0]&0xF8 == 0xF0 {
-			r = rune(buf[0]&0x07)<<18 | rune(buf[1]&0x3F)<<12 | rune(buf[2]&0x3F)<<6 | rune(buf[3]&0x3F)
+		// decode every UTF-8 rune, dropping controls and broken bytes
+		var text strings.Builder
+		for b := buf[:n]; len(b) > 0; {
+			r, size := utf8.DecodeRune(b)
+			b = b[size:]
+			if r != utf8.RuneError && r >= 32 && r != 127 {
+				text.WriteRune(r)
+			}
+		}
+		if text.Len() == 0 {
+			return keyEvent{kind: evNone}, nil
 		}
-		return keyEvent{kind: evChar, ch: r}
+		r, _ := utf8.DecodeRuneInString(text.String())
+		return keyEvent{kind: evChar, ch: r, text: text.String()}, nil
 	}
-	return keyEvent{kind: evNone}
+	return keyEvent{kind: evNone}, nil
 }
 
 // ════════════════════════════════════════════════════════════════════
@@ -1129,112 +1946,131 @@ const (
 	stLineEnd
 	stResults
 	stSpaceInv // Space Invaders game
+	stTrack    // lesson list of a track
+	stProgress // progress charts
 )
 
-// keyChan starts a background goroutine that reads keys and sends them on a channel.
-func keyChan() <-chan keyEvent {
-	ch := make(chan keyEvent, 8)
-	go func() {
-		buf := make([]byte, 16)
-		for {
-			k := readKey(buf)
-			ch <- k
-		}
-	}()
-	return ch
+// keyReader turns a byte stream into key events on a channel until it
+// is stopped or the stream ends. Terminals embed it for Events.
+type keyReader struct {
+	events chan keyEvent
+	done   chan struct{}
+	once   sync.Once
 }
 
-// runSpaceInvaders runs the Space Invaders game loop with its own ticker.
-// Returns the action to take: "menu", "quit", or "".
-func runSpaceInvaders(keys <-chan keyEvent) string {
-	game := newSpaceGame()
-	game.lastRender = time.Now()
-	renderSpaceGame(game, true)
-
-	ticker := time.NewTicker(game.tickRate)
-	defer ticker.Stop()
-
-	var mu sync.Mutex
+func newKeyReader(in io.Reader) *keyReader {
+	kr := &keyReader{events: make(chan keyEvent, 8), done: make(chan struct{})}
+	go kr.loop(in)
+	return kr
+}
 
+func (kr *keyReader) loop(in io.Reader) {
+	buf := make([]byte, 256)
 	for {
+		k, err := readKey(in, buf)
+		if err != nil {
+			k = keyEvent{kind: evCtrlC}
+		}
 		select {
-		case <-ticker.C:
-			mu.Lock()
-			if !game.gameOver {
-				game.update()
-				renderSpaceGame(game, false)
-			}
-			mu.Unlock()
+		case <-kr.done:
+			return
+		default:
+		}
+		select {
+		case kr.events <- k:
+		case <-kr.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
 
-		case k := <-keys:
-			mu.Lock()
-			if k.kind == evCtrlC {
-				mu.Unlock()
-				return "quit"
-			}
-			if k.kind == evEscape {
-				mu.Unlock()
-				return "menu"
-			}
+func (kr *keyReader) Events() <-chan keyEvent { return kr.events }
 
-			if game.gameOver {
-				if k.kind == evChar {
-					switch k.ch {
-					case 'r', 'R':
-						game = newSpaceGame()
-						game.lastRender = time.Now()
-						renderSpaceGame(game, true)
-					case 'm', 'M':
-						mu.Unlock()
-						return "menu"
-					case 'q', 'Q':
-						mu.Unlock()
-						return "quit"
-					}
-				}
-			} else {
-				if k.kind == evChar {
-					ch := k.ch
-					if ch >= 'A' && ch <= 'Z' {
-						ch = ch - 'A' + 'a'
-					}
-					if game.tryShoot(ch) {
-						renderSpaceGame(game, false)
-					}
-				}
-			}
-			mu.Unlock()
-		}
+// stop ends delivery at once. A read already in progress is abandoned
+// as soon as it returns.
+func (kr *keyReader) stop() { kr.once.Do(func() { close(kr.done) }) }
+
+// gameSeed returns the -seed flag, or a fresh seed when none was given.
+// Every game is built from it, so -seed makes any game reproducible.
+func gameSeed() int64 {
+	if *seedFlag != 0 {
+		return *seedFlag
 	}
+	return time.Now().UnixNano()
 }
 
-func run() error {
-	fd := int(os.Stdin.Fd())
-	old, err := term.MakeRaw(fd)
+// defaultSettings are the options every new ui starts with, taken
+// from the command-line flags.
+var defaultSettings Settings
+
+// withConsole runs fn on the local console, feeding it keys, and
+// restores the terminal afterwards.
+func withConsole(fn func(u *ui, keys <-chan keyEvent) error) error {
+	c, err := newConsole()
 	if err != nil {
-		return fmt.Errorf("failed to enter raw mode: %w", err)
+		return err
 	}
-	defer func() {
-		showCur()
-		term.Restore(fd, old)
-	}()
+	defer c.Close()
+	u := newUI(c, defaultSettings)
+	u.hideCur()
+	defer u.showCur()
+	return fn(u, c.Events())
+}
+
+// run shows the menu until the user quits. The caller owns the
+// terminal and closes it afterwards, so any Terminal can host tt.
+func (u *ui) run() error {
+	u.loadHistory()
+	u.hideCur()
+	defer u.showCur()
+	u.write(keypadAppMode)
+	defer u.write(keypadNumMode)
+	defer u.saveProfile()
+	keys := u.term.Events()
+	if u.profiles != nil && u.profile == nil {
+		// several profiles and no -user: ask who is practising
+		if u.runProfiles(keys) == "quit" {
+			u.goodbye()
+			return nil
+		}
+	}
+	return u.runMenu(keys)
+}
 
-	hideCur()
-	keys := keyChan()
-	nMenu := len(lessons) + 1 // lessons + Space Invaders
+// runMenu is the main state machine: menu, lessons and games.
+func (u *ui) runMenu(keys <-chan keyEvent) error {
+	items := menuItems()
+	nMenu := len(items)
 	sel := 0
 	state := stMenu
 	var sess *Session
+	var track *Track // track the current lesson was started from
+	tsel := 0
+	var hist []Result // history shown on the progress screen
+	psel := 0
+
+	// toMenu returns to the menu the current lesson was picked from.
+	toMenu := func() {
+		if track != nil {
+			state = stTrack
+			u.renderTrackMenu(track, tsel, true)
+			return
+		}
+		state = stMenu
+		u.renderMenu(sel, true)
+	}
 
-	renderMenu(sel, true)
+	u.renderMenu(sel, true)
 
 	for {
 		k := <-keys
 
 		// Ctrl-C always quits
 		if k.kind == evCtrlC {
-			cls()
-			emit("Goodbye!\n")
+			u.goodbye()
 			return nil
 		}
 
@@ -1246,65 +2082,215 @@ func run() error {
 				if sel > 0 {
 					sel--
 				}
-				renderMenu(sel, false)
+				u.renderMenu(sel, false)
 			case evDown:
 				if sel < nMenu-1 {
 					sel++
 				}
-				renderMenu(sel, false)
+				u.renderMenu(sel, false)
+			case evRight:
+				// jump across to the right column
+				if sel < len(lessons) {
+					sel = len(lessons) + min(sel, nMenu-len(lessons)-1)
+				}
+				u.renderMenu(sel, false)
+			case evLeft:
+				if sel >= len(lessons) {
+					sel = min(sel-len(lessons), len(lessons)-1)
+				}
+				u.renderMenu(sel, false)
 			case evEnter:
-				if sel == len(lessons) {
-					// Space Invaders — runs its own loop
-					result := runSpaceInvaders(keys)
+				switch it := items[sel]; {
+				case it.game != nil:
+					// games run their own loop
+					result := u.runGame(keys, it.game.Name, it.game.New(gameSeed()))
 					switch result {
 					case "quit":
-						cls()
-						emit("Goodbye!\n")
+						u.goodbye()
 						return nil
 					default: // "menu"
 						state = stMenu
-						renderMenu(sel, true)
+						u.renderMenu(sel, true)
 					}
-				} else {
-					sess = newSession(&lessons[sel])
+				case it.drills:
+					if u.runDrills(keys) == "quit" {
+						u.goodbye()
+						return nil
+					}
+					state = stMenu
+					u.renderMenu(sel, true)
+				case it.track != nil:
+					track, tsel = it.track, 0
+					state = stTrack
+					u.renderTrackMenu(track, tsel, true)
+				case !u.unlocked(sel):
+					u.bell()
+				default:
+					track = nil
+					sess = newSession(it.lesson)
 					state = stTyping
-					renderTyping(sess, true)
+					u.renderTyping(sess, true)
 				}
 			case evChar:
 				switch k.ch {
 				case 'q', 'Q':
-					cls()
-					emit("Goodbye!\n")
+					u.goodbye()
 					return nil
+				case 'v', 'V':
+					u.settings.toggleView()
+					u.renderMenu(sel, false)
+				case 'g', 'G':
+					u.settings.toggleGuide()
+					u.renderMenu(sel, false)
+				case 'c', 'C':
+					u.settings.toggleCurriculum()
+					u.renderMenu(sel, false)
+				case 'u', 'U':
+					if u.profiles == nil {
+						break
+					}
+					if u.runProfiles(keys) == "quit" {
+						u.goodbye()
+						return nil
+					}
+					u.renderMenu(sel, true)
+				case 'p', 'P':
+					hist, psel = u.results, 0
+					state = stProgress
+					u.renderProgress(hist, psel, true)
 				default:
-					if k.ch >= '1' && k.ch <= rune('0'+nMenu) {
+					if k.ch >= '1' && k.ch <= '9' && int(k.ch-'1') < nMenu {
 						sel = int(k.ch - '1')
-						renderMenu(sel, false)
+						u.renderMenu(sel, false)
 					}
 				}
 			case evEscape:
-				cls()
-				emit("Goodbye!\n")
+				u.goodbye()
 				return nil
 			}
 
+		// ── Progress charts ───────────────────────────────
+		case stProgress:
+			n := len(progressLessons(hist))
+			switch {
+			case k.kind == evLeft:
+				psel = (psel + n - 1) % n
+				u.renderProgress(hist, psel, false)
+			case k.kind == evRight:
+				psel = (psel + 1) % n
+				u.renderProgress(hist, psel, false)
+			case k.kind == evEscape || k.kind == evChar && (k.ch == 'm' || k.ch == 'M'):
+				state = stMenu
+				u.renderMenu(sel, true)
+			case k.kind == evChar && (k.ch == 'q' || k.ch == 'Q'):
+				u.goodbye()
+				return nil
+			}
+
+		// ── Track lesson list ─────────────────────────────
+		case stTrack:
+			switch k.kind {
+			case evUp:
+				if tsel > 0 {
+					tsel--
+				}
+				u.renderTrackMenu(track, tsel, false)
+			case evDown:
+				if tsel < len(u.settings.trackLessons(track))-1 {
+					tsel++
+				}
+				u.renderTrackMenu(track, tsel, false)
+			case evEnter:
+				sess = newSession(u.settings.practiceLesson(&u.settings.trackLessons(track)[tsel]))
+				state = stTyping
+				u.renderTyping(sess, true)
+			case evEscape:
+				track = nil
+				state = stMenu
+				u.renderMenu(sel, true)
+			case evChar:
+				switch k.ch {
+				case 'q', 'Q':
+					u.goodbye()
+					return nil
+				case 'm', 'M':
+					track = nil
+					state = stMenu
+					u.renderMenu(sel, true)
+				case 'r', 'R':
+					if trackIsCJK(track) {
+						u.settings.Romanize = !u.settings.Romanize
+						u.renderTrackMenu(track, tsel, false)
+					}
+				case 'z', 'Z':
+					if trackIsCJK(track) {
+						u.settings.Zhuyin = !u.settings.Zhuyin
+						u.renderTrackMenu(track, tsel, false)
+					}
+				case 'l', 'L':
+					if track.Layout {
+						u.settings.cycleLayout()
+						u.renderTrackMenu(track, tsel, true)
+					}
+				case 'k', 'K':
+					if track.Layout {
+						u.settings.Remap = !u.settings.Remap
+						u.renderTrackMenu(track, tsel, false)
+					}
+				case 'p', 'P':
+					if track.Generator {
+						u.settings.GenPunct = !u.settings.GenPunct
+						u.renderTrackMenu(track, tsel, false)
+					}
+				case 'c', 'C':
+					if track.Generator {
+						u.settings.GenCaps = !u.settings.GenCaps
+						u.renderTrackMenu(track, tsel, false)
+					}
+				case 'n', 'N':
+					if track.Generator {
+						u.settings.GenNumbers = !u.settings.GenNumbers
+						u.renderTrackMenu(track, tsel, false)
+					}
+				default:
+					if k.ch >= '1' && k.ch <= '9' && int(k.ch-'1') < len(u.settings.trackLessons(track)) {
+						tsel = int(k.ch - '1')
+						u.renderTrackMenu(track, tsel, false)
+					}
+				}
+			}
+
 		// ── Typing ────────────────────────────────────────
 		case stTyping:
+			if k.kind == evEnter && sess.lesson.Keypad {
+				// the keypad has no space bar: Enter ends an entry
+				k = keyEvent{kind: evChar, ch: ' ', text: " "}
+			}
 			switch k.kind {
 			case evEscape:
-				state = stMenu
-				renderMenu(sel, true)
+				toMenu()
 			case evBackspace:
 				sess.backspace()
-				renderTyping(sess, false)
+				u.renderTyping(sess, false)
+			case evTab:
+				u.settings.toggleView()
+				u.renderTyping(sess, true)
 			case evChar:
-				sess.addRune(k.ch)
+				k = u.settings.remapKey(k)
+				for _, r := range k.text {
+					if sess.addRune(r) {
+						u.bell()
+					}
+					if sess.lineFinished() {
+						break
+					}
+				}
 				if sess.lineFinished() {
 					st := sess.finishLine()
 					state = stLineEnd
-					renderLineComplete(sess, st)
+					u.renderLineComplete(sess, st)
 				} else {
-					renderTyping(sess, false)
+					u.renderTyping(sess, false)
 				}
 			}
 
@@ -1316,11 +2302,12 @@ func run() error {
 			}
 			if sess.allDone() {
 				state = stResults
-				renderResults(sess)
+				u.record(sess)
+				u.renderResults(sess)
 			} else {
 				sess.advanceLine()
 				state = stTyping
-				renderTyping(sess, true)
+				u.renderTyping(sess, true)
 			}
 
 		// ── Results ───────────────────────────────────────
@@ -1331,25 +2318,181 @@ func run() error {
 				case 'r', 'R':
 					sess = newSession(sess.lesson)
 					state = stTyping
-					renderTyping(sess, true)
+					u.renderTyping(sess, true)
+				case 'n', 'N':
+					if sess.lesson.next != nil {
+						sess = newSession(u.settings.practiceLesson(sess.lesson))
+						state = stTyping
+						u.renderTyping(sess, true)
+					}
 				case 'm', 'M':
-					state = stMenu
-					renderMenu(sel, true)
+					toMenu()
 				case 'q', 'Q':
-					cls()
-					emit("Goodbye!\n")
+					u.goodbye()
 					return nil
 				}
 			case evEscape:
-				state = stMenu
-				renderMenu(sel, true)
+				toMenu()
 			}
 		}
 	}
 }
 
+var (
+	seedFlag   = flag.Int64("seed", 0, "random seed for the games; equal seeds give identical alien and word sequences")
+	layoutFlag = flag.String("layout", "qwerty", "keyboard layout: qwerty, dvorak, colemak, azerty or qwertz")
+	remapFlag  = flag.Bool("remap", false, "treat the physical keyboard as QWERTY and translate keys to -layout")
+	nameFlag   = flag.String("name", defaultName(), "your name in network races")
+	hostKey    = flag.String("hostkey", defaultHostKeyPath(), "serve-ssh host key, generated when missing")
+	userFlag   = flag.String("user", "", "profile to practise as, created when new; asked at startup when there are several")
+	histFlag   = flag.String("history", "", "file finished lessons are recorded in (default: the profile's history)")
+	jsonFlag   = flag.Bool("json", false, "print the last finished lesson as JSON on exit")
+	curFlag    = flag.Bool("curriculum", false, "start in curriculum mode: lessons unlock as their targets are met")
+	targetFlag = flag.String("targets", "", "JSON file of lesson targets, as printed by tt targets")
+	classFlag  = flag.String("classroom", "", "send every finished lesson to the teacher's tt classroom at this address")
+	packFlag   = flag.String("pack", "", "homework pack to add as a track; passing all its lessons writes a signed record")
+	corpusFlag = flag.String("corpus", "", "text file or directory of your own writing to generate practice lines from; adds a track")
+	gradeFlag  = flag.String("grading", "standard", "default grading: standard, beginner, professional or chinese-exam")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	fmt.Fprintf(os.Stderr, "  tt [flags]               practise lessons and play games\n")
+	fmt.Fprintf(os.Stderr, "  tt [flags] host [addr]   host a LAN race (default port %s)\n", raceDefaultPort)
+	fmt.Fprintf(os.Stderr, "  tt [flags] join <addr>   join a LAN race\n")
+	fmt.Fprintf(os.Stderr, "  tt [flags] versus [addr] Space Invaders head to head: wait for an opponent\n")
+	fmt.Fprintf(os.Stderr, "                              on [:port], or join one (default port %s)\n", versusDefaultPort)
+	fmt.Fprintf(os.Stderr, "  tt [flags] serve-ssh [addr] let anyone practise over ssh (default %s)\n", sshDefaultAddr)
+	fmt.Fprintf(os.Stderr, "  tt [flags] export [-format csv|json] [-o file]  write the lesson history\n")
+	fmt.Fprintf(os.Stderr, "  tt [flags] classroom [addr] [-dir shared] [-format csv|html] [-o file]\n")
+	fmt.Fprintf(os.Stderr, "                              collect the class's results (default port %s)\n", classroomDefaultPort)
+	fmt.Fprintf(os.Stderr, "  tt [flags] issue pack.json student...\n")
+	fmt.Fprintf(os.Stderr, "                              write each student's copy of a homework pack\n")
+	fmt.Fprintf(os.Stderr, "  tt [flags] verify [-pack pack.json | -key key] result.json\n")
+	fmt.Fprintf(os.Stderr, "                              check a signed homework record\n")
+	fmt.Fprintf(os.Stderr, "  tt [flags] quotes [import file...]  count the quotes, or add a collection\n")
+	fmt.Fprintf(os.Stderr, "  tt [flags] targets          print the lesson targets as JSON, to edit for -targets\n\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// applyFlags puts the settings given on the command line over those a
+// profile saved.
+func applyFlags(st *Settings) {
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "layout", "remap":
+			st.Layout, st.Remap = defaultSettings.Layout, defaultSettings.Remap
+		case "curriculum":
+			st.Curriculum = defaultSettings.Curriculum
+		}
+	})
+}
+
 func main() {
-	if err := run(); err != nil {
+	flag.Usage = usage
+	flag.Parse()
+	layout, err := layoutByName(*layoutFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	defaultSettings.Layout, defaultSettings.Remap = layout, *remapFlag
+	defaultSettings.Curriculum = *curFlag
+	if defaultGrading, err = gradingByName(*gradeFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	if *targetFlag != "" {
+		if err := loadTargets(*targetFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	if *packFlag != "" {
+		pk, err := loadPack(*packFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		tracks = append(tracks, packTrack(pk))
+	}
+	if userQuotes, err = loadUserQuotes(quotesPath()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	if *corpusFlag != "" {
+		m, err := loadCorpus(*corpusFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		tracks = append(tracks, corpusTrack(*corpusFlag, m))
+	}
+
+	switch flag.Arg(0) {
+	case "":
+		var c *console
+		if c, err = newConsole(); err == nil {
+			u := newUI(c, defaultSettings)
+			u.profiles = newProfiles(defaultProfilesDir())
+			u.histPath = *histFlag
+			var p *Profile
+			if p, err = startProfile(u.profiles, *userFlag); err != nil {
+				c.Close()
+				break
+			}
+			if p != nil {
+				u.useProfile(p)
+				applyFlags(&u.settings)
+			}
+			if u.histPath != "" && p == nil {
+				u.history = newHistory(u.histPath)
+			}
+			u.classroom = *classFlag
+			err = u.run()
+			c.Close()
+			// printed once the terminal is back to normal
+			if *jsonFlag && u.last != nil {
+				writeJSON(os.Stdout, u.last)
+			}
+		}
+	case "host":
+		err = hostRace(flag.Arg(1), *nameFlag)
+	case "join":
+		if flag.NArg() < 2 {
+			usage()
+			os.Exit(2)
+		}
+		err = joinRace(flag.Arg(1), *nameFlag)
+	case "versus":
+		err = playVersus(flag.Arg(1), *nameFlag)
+	case "targets":
+		err = writeJSON(os.Stdout, lessonTargets())
+	case "export":
+		h := newHistory(*histFlag)
+		if *histFlag == "" {
+			var p *Profile
+			if p, err = exportProfile(newProfiles(defaultProfilesDir()), *userFlag); err != nil {
+				break
+			}
+			h = newHistory(p.historyPath())
+		}
+		err = exportHistory(h, flag.Args()[1:])
+	case "issue":
+		err = issue(flag.Args()[1:])
+	case "verify":
+		err = verify(flag.Args()[1:])
+	case "classroom":
+		err = classroom(flag.Args()[1:])
+	case "quotes":
+		err = quotesCmd(flag.Args()[1:])
+	case "serve-ssh":
+		err = serveSSH(flag.Arg(1), *hostKey)
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\r\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Corpus — Markov-chain practice text trained on the user's own writing
+// ════════════════════════════════════════════════════════════════════
+
+const (
+	markovOrder   = 2       // words of context each next word is chosen by
+	markovMaxWord = 30      // wider tokens (URLs, hashes) are left out
+	markovMaxFile = 8 << 20 // larger files are skipped as unlikely to be prose
+	markovMinWord = 100     // a corpus needs this many words to train on
+)
+
+// markov is a word-level n-gram model. Next maps the last markovOrder
+// words, space-joined, to every word that followed them in the corpus;
+// repeats keep the frequencies. Starts are the states a sentence or
+// file began with.
+type markov struct {
+	Order  int                 `json:"order"`
+	Next   map[string][]string `json:"next"`
+	Starts []string            `json:"starts"`
+}
+
+// asciiPunct turns typographic punctuation into what a keyboard types.
+var asciiPunct = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "′", "'",
+	"“", `"`, "”", `"`, "„", `"`, "″", `"`, "«", `"`, "»", `"`,
+	"–", "-", "—", " - ", "―", " - ", "‐", "-", "‑", "-",
+	"…", "...", "\u00a0", " ",
+)
+
+// typeable reports whether every rune of w is on a keyboard: a letter,
+// digit or accent of any script, or printable ASCII. Emoji and symbols
+// such as ™ are not.
+func typeable(w string) bool {
+	for _, r := range w {
+		if r > unicode.MaxASCII && !unicode.In(r, unicode.L, unicode.M, unicode.Nd) || r <= unicode.MaxASCII && !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// trainMarkov builds a model from texts. Typographic punctuation is
+// typed as its ASCII look-alike; words that still cannot be typed are
+// left out.
+func trainMarkov(texts []string) (*markov, error) {
+	m := &markov{Order: markovOrder, Next: map[string][]string{}}
+	total := 0
+	for _, text := range texts {
+		var words []string
+		for _, w := range strings.Fields(asciiPunct.Replace(text)) {
+			if vLen(w) <= markovMaxWord && typeable(w) {
+				words = append(words, w)
+			}
+		}
+		total += len(words)
+		for i := 0; i+m.Order < len(words); i++ {
+			state := strings.Join(words[i:i+m.Order], " ")
+			if i == 0 || strings.ContainsAny(words[i-1][len(words[i-1])-1:], ".!?") {
+				m.Starts = append(m.Starts, state)
+			}
+			m.Next[state] = append(m.Next[state], words[i+m.Order])
+		}
+	}
+	if total < markovMinWord || len(m.Starts) == 0 {
+		return nil, fmt.Errorf("corpus has %d words; it needs at least %d", total, markovMinWord)
+	}
+	return m, nil
+}
+
+// text deals genLines lines of generated text. A chain that reaches a
+// state the corpus never continued starts over from a sentence start.
+func (m *markov) text(rng *rand.Rand) []string {
+	var state []string
+	var pending []string // words of a fresh start not yet dealt
+	return fillLines(func() string {
+		if len(pending) == 0 {
+			next := m.Next[strings.Join(state, " ")]
+			if len(next) == 0 {
+				pending = strings.Fields(m.Starts[rng.Intn(len(m.Starts))])
+				state = nil
+			} else {
+				pending = []string{next[rng.Intn(len(next))]}
+			}
+		}
+		w := pending[0]
+		pending = pending[1:]
+		state = append(state, w)
+		if len(state) > m.Order {
+			state = state[1:]
+		}
+		return w
+	})
+}
+
+// corpusFiles lists the files of a corpus: path itself, or the files
+// under it, leaving out hidden ones and those too large to be prose.
+func corpusFiles(path string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != path && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > markovMaxFile {
+			return nil
+		}
+		files = append(files, p)
+		return nil
+	})
+	if err == nil && len(files) == 0 {
+		err = errors.New("no files to train on")
+	}
+	return files, err
+}
+
+// corpusKey identifies a corpus by its files' paths, sizes and times,
+// so an unchanged corpus is not read again.
+func corpusKey(files []string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "order %d, ascii punctuation\n", markovOrder)
+	for _, f := range files {
+		abs, _ := filepath.Abs(f)
+		if info, err := os.Stat(f); err == nil {
+			fmt.Fprintf(h, "%s %d %d\n", abs, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// markovCachePath is where the model of the corpus with key is kept.
+func markovCachePath(key string) string {
+	return filepath.Join(dataDir(), "corpus", key+".json")
+}
+
+// loadCorpus returns the model of the corpus at path: the cached one
+// while the corpus is unchanged, or else a fresh one, which is cached.
+// Binary files are left out.
+func loadCorpus(path string) (*markov, error) {
+	files, err := corpusFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	cache := markovCachePath(corpusKey(files))
+	if data, err := os.ReadFile(cache); err == nil {
+		var m markov
+		if json.Unmarshal(data, &m) == nil && m.Order == markovOrder && len(m.Starts) > 0 {
+			return &m, nil
+		}
+	}
+	var texts []string
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if utf8.Valid(data) && !bytes.ContainsRune(data, 0) {
+			texts = append(texts, string(data))
+		}
+	}
+	m, err := trainMarkov(texts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if data, err := json.Marshal(m); err == nil {
+		if os.MkdirAll(filepath.Dir(cache), 0o755) == nil {
+			os.WriteFile(cache, data, 0o644)
+		}
+	}
+	return m, nil
+}
+
+// corpusTrack is the menu track a corpus is practised from. Its lesson
+// deals fresh lines for each run, like the word generator's.
+func corpusTrack(path string, m *markov) Track {
+	name := filepath.Base(filepath.Clean(path))
+	lesson := "Generated from " + name
+	return Track{Name: "Corpus: " + name, Lessons: []Lesson{{
+		Name: lesson,
+		next: func(*Settings) *Lesson {
+			return &Lesson{Name: lesson, Lines: m.text(rand.New(rand.NewSource(gameSeed())))}
+		},
+	}}}
+}
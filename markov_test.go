@@ -0,0 +1,100 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCorpus = `The quick brown fox jumps over the lazy dog. The lazy dog sleeps in the sun
+all day long, and the quick brown fox runs off into the woods. In the woods the fox
+finds a river; the river is cold and clear. The dog wakes up and looks for the fox,
+but the fox is gone. So the dog goes back to sleep in the warm afternoon sun.
+`
+
+func TestMarkovUsesCorpusWords(t *testing.T) {
+	text := strings.Repeat(testCorpus, 2)
+	m, err := trainMarkov([]string{text})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vocab := map[string]bool{}
+	for _, w := range strings.Fields(text) {
+		vocab[w] = true
+	}
+	lines := m.text(rand.New(rand.NewSource(1)))
+	if len(lines) != genLines {
+		t.Fatalf("got %d lines, want %d", len(lines), genLines)
+	}
+	for _, line := range lines {
+		if n := vLen(line); n > genWidth {
+			t.Errorf("%q is %d columns, want at most %d", line, n, genWidth)
+		}
+		for _, w := range strings.Fields(line) {
+			if !vocab[w] {
+				t.Errorf("%q is not in the corpus", w)
+			}
+		}
+	}
+}
+
+func TestMarkovNeedsEnoughWords(t *testing.T) {
+	if _, err := trainMarkov([]string{"too short to learn from."}); err == nil {
+		t.Error("trained on a five-word corpus")
+	}
+}
+
+func TestLoadCorpusCaches(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte(strings.Repeat(testCorpus, 2)), 0o644)
+	os.WriteFile(filepath.Join(dir, "b.bin"), []byte("binary\x00data"), 0o644)
+	os.Mkdir(filepath.Join(dir, ".git"), 0o755)
+	os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0o644)
+
+	files, err := corpusFiles(dir)
+	if err != nil || len(files) != 2 {
+		t.Fatalf("corpus files %v, %v; want a.txt and b.bin", files, err)
+	}
+	m, err := loadCorpus(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Next["binary data"]; ok {
+		t.Error("trained on a binary file")
+	}
+	cache := markovCachePath(corpusKey(files))
+	if _, err := os.Stat(cache); err != nil {
+		t.Fatalf("model not cached: %v", err)
+	}
+	os.WriteFile(cache, []byte(`{"order":2,"next":{},"starts":["cached model"]}`), 0o644)
+	if m, err = loadCorpus(dir); err != nil || m.Starts[0] != "cached model" {
+		t.Errorf("unchanged corpus retrained: %v", err)
+	}
+
+	tr := corpusTrack(dir, m)
+	l := (&Settings{}).practiceLesson(&tr.Lessons[0])
+	if len(l.Lines) != genLines || !strings.HasPrefix(l.Lines[0], "cached model") {
+		t.Errorf("corpus lesson lines %q", l.Lines)
+	}
+}
+
+func TestMarkovTypeableText(t *testing.T) {
+	text := strings.Repeat("“Don’t wait,” she said — it’s late… Let’s go 🚀 now™ café. ", 20)
+	m, err := trainMarkov([]string{text})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range m.text(rand.New(rand.NewSource(1))) {
+		for _, w := range strings.Fields(line) {
+			if !typeable(w) || strings.ContainsAny(w, "“”’—…") {
+				t.Errorf("%q cannot be typed", w)
+			}
+		}
+		if !strings.Contains(line, `"Don't`) || !strings.Contains(line, "late...") || !strings.Contains(line, "café.") {
+			t.Errorf("%q lost the typeable words", line)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Profiles — one history, settings and high-score table per person
+// ════════════════════════════════════════════════════════════════════
+
+const profileNameMax = 20
+
+// Profiles is the directory holding one sub-directory per profile.
+type Profiles struct{ dir string }
+
+func newProfiles(dir string) *Profiles { return &Profiles{dir: dir} }
+
+func defaultProfilesDir() string { return filepath.Join(dataDir(), "profiles") }
+
+// Profile is one person's saved state. Their history sits next to it.
+type Profile struct {
+	Name       string                `json:"-"`
+	Settings   Settings              `json:"settings"`
+	HighScores map[string]int        `json:"high_scores"`
+	Cards      map[string]*cardState `json:"cards,omitempty"` // shortcut drill progress by deck/prompt
+
+	dir string
+}
+
+// validProfileName reports why name cannot name a profile, or nil.
+func validProfileName(name string) error {
+	if name == "" {
+		return errors.New("a name is needed")
+	}
+	if len([]rune(name)) > profileNameMax {
+		return fmt.Errorf("at most %d characters", profileNameMax)
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" -_.", r) {
+			return fmt.Errorf("%q is not allowed in a name", r)
+		}
+	}
+	if strings.HasPrefix(name, ".") || strings.TrimSpace(name) != name {
+		return errors.New("no leading dot or outer spaces")
+	}
+	return nil
+}
+
+// list returns the profile names in alphabetical order.
+func (ps *Profiles) list() ([]string, error) {
+	ents, err := os.ReadDir(ps.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range ents {
+		if e.IsDir() && validProfileName(e.Name()) == nil {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (ps *Profiles) exists(name string) bool {
+	st, err := os.Stat(filepath.Join(ps.dir, name))
+	return err == nil && st.IsDir()
+}
+
+// create makes a new profile starting from settings st. The very first
+// profile adopts a history kept from before profiles existed.
+func (ps *Profiles) create(name string, st Settings) (*Profile, error) {
+	if err := validProfileName(name); err != nil {
+		return nil, err
+	}
+	if ps.exists(name) {
+		return nil, fmt.Errorf("profile %q already exists", name)
+	}
+	names, _ := ps.list()
+	p := &Profile{Name: name, Settings: st, HighScores: map[string]int{}, dir: filepath.Join(ps.dir, name)}
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		os.Rename(defaultHistoryPath(), p.historyPath())
+	}
+	return p, p.save()
+}
+
+// open loads an existing profile.
+func (ps *Profiles) open(name string) (*Profile, error) {
+	if err := validProfileName(name); err != nil {
+		return nil, err
+	}
+	p := &Profile{Name: name, dir: filepath.Join(ps.dir, name)}
+	data, err := os.ReadFile(filepath.Join(p.dir, "profile.json"))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+	if p.HighScores == nil {
+		p.HighScores = map[string]int{}
+	}
+	if p.Settings.Layout < 0 || p.Settings.Layout >= len(layouts) {
+		p.Settings.Layout = 0
+	}
+	return p, nil
+}
+
+// openOrCreate opens name, creating it from st when it is new.
+func (ps *Profiles) openOrCreate(name string, st Settings) (*Profile, error) {
+	if ps.exists(name) {
+		return ps.open(name)
+	}
+	return ps.create(name, st)
+}
+
+func (ps *Profiles) rename(old, name string) error {
+	if err := validProfileName(name); err != nil {
+		return err
+	}
+	if ps.exists(name) {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	return os.Rename(filepath.Join(ps.dir, old), filepath.Join(ps.dir, name))
+}
+
+func (ps *Profiles) remove(name string) error {
+	if validProfileName(name) != nil {
+		return fmt.Errorf("no profile %q", name)
+	}
+	return os.RemoveAll(filepath.Join(ps.dir, name))
+}
+
+// lastPath keeps the name of the profile used most recently, which
+// `tt export` falls back to.
+func (ps *Profiles) lastPath() string { return filepath.Join(ps.dir, ".last") }
+
+func (ps *Profiles) setLast(name string) { os.WriteFile(ps.lastPath(), []byte(name), 0o644) }
+
+func (ps *Profiles) last() string {
+	data, _ := os.ReadFile(ps.lastPath())
+	return strings.TrimSpace(string(data))
+}
+
+func (p *Profile) historyPath() string { return filepath.Join(p.dir, "history.jsonl") }
+
+func (p *Profile) save() error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(p.dir, "profile.json"), append(data, '\n'), 0o644)
+}
+
+// useProfile switches the ui to p: its settings, history and scores.
+// A history given with -history stays in use across switches.
+func (u *ui) useProfile(p *Profile) {
+	u.saveProfile()
+	u.profile = p
+	u.settings = p.Settings
+	path := p.historyPath()
+	if u.histPath != "" {
+		path = u.histPath
+	}
+	u.history = newHistory(path)
+	u.loadHistory()
+	u.profiles.setLast(p.Name)
+}
+
+// saveProfile stores the current settings in the active profile.
+func (u *ui) saveProfile() {
+	if u.profile != nil {
+		u.profile.Settings = u.settings
+		u.profile.save()
+	}
+}
+
+// noteScore keeps the final score of game g as a high score of the
+// active profile when it beats the old one.
+func (u *ui) noteScore(game string, g Game) {
+	s, ok := g.(scorer)
+	if !ok || game == "" || u.profile == nil {
+		return
+	}
+	if n, over := s.finalScore(); over && n > u.profile.HighScores[game] {
+		u.profile.HighScores[game] = n
+		u.profile.save()
+	}
+}
+
+// profileSummary is the line under a profile in the profile list.
+func (ps *Profiles) profileSummary(name string) string {
+	rs, _ := newHistory(filepath.Join(ps.dir, name, "history.jsonl")).load()
+	best := 0.0
+	for _, r := range rs {
+		best = max(best, r.WPM)
+	}
+	s := fmt.Sprintf("%d lessons finished", len(rs))
+	if len(rs) > 0 {
+		s += fmt.Sprintf(", best %.0f WPM", best)
+	}
+	return s
+}
+
+// ── Profile screen ───────────────────────────────────────────────────
+
+// profileScreen is the state of the profile screen.
+type profileScreen struct {
+	names []string
+	sel   int
+	mode  string // "", "new", "rename" or "delete"
+	input []rune // name being typed
+	msg   string // error or hint shown above the keys
+}
+
+func (u *ui) renderProfiles(ps *profileScreen, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"TT — Profiles"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTTitle+"Who is practising?"+RST+TTBg) + "\n")
+	b.WriteString(hBlank() + "\n")
+	const shown = 9
+	top := max(0, min(ps.sel-shown/2, len(ps.names)-shown))
+	for i := top; i < len(ps.names) && i < top+shown; i++ {
+		name := ps.names[i]
+		cell := menuCell(i, ps.sel, name)
+		if u.profile != nil && name == u.profile.Name {
+			cell = strings.TrimRight(cell, " ") + TTDim + "  (current)" + RST + TTBg
+		}
+		b.WriteString(hRow(cell) + "\n")
+	}
+	if len(ps.names) == 0 {
+		b.WriteString(hRow(TTDim+"No profiles yet — press N to create one."+RST+TTBg) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	if ps.sel < len(ps.names) {
+		name := ps.names[ps.sel]
+		b.WriteString(hRow(fmt.Sprintf("%s%s%s: %s", FgCyn+BOLD, name, RST+TTBg, u.profiles.profileSummary(name))) + "\n")
+		if p, err := u.profiles.open(name); err == nil && len(p.HighScores) > 0 {
+			var hs []string
+			for _, g := range games {
+				if n, ok := p.HighScores[g.Name]; ok {
+					hs = append(hs, fmt.Sprintf("%s %s%d%s", g.Name, FgYlw+BOLD, n, RST+TTBg))
+				}
+			}
+			b.WriteString(hRow("High scores: "+strings.Join(hs, " · ")) + "\n")
+		}
+	}
+	switch ps.mode {
+	case "new":
+		b.WriteString(hRow(fmt.Sprintf("New profile name: %s%s▌%s", FgGrn+BOLD, string(ps.input), RST+TTBg)) + "\n")
+	case "rename":
+		b.WriteString(hRow(fmt.Sprintf("Rename %s to: %s%s▌%s", ps.names[ps.sel], FgGrn+BOLD, string(ps.input), RST+TTBg)) + "\n")
+	case "delete":
+		b.WriteString(hRow(fmt.Sprintf("%sDelete %s and all their results? Y/N%s", FgRed+BOLD, ps.names[ps.sel], RST+TTBg)) + "\n")
+	}
+	if ps.msg != "" {
+		b.WriteString(hRow(FgRed+ps.msg+RST+TTBg) + "\n")
+	}
+	b.WriteString(hMid() + "\n")
+	if ps.mode == "new" || ps.mode == "rename" {
+		b.WriteString(hRow(TTDim+"Enter Save │ ESC Cancel"+RST+TTBg) + "\n")
+	} else {
+		b.WriteString(hRow(TTDim+"Enter Use │ N New │ R Rename │ D Delete │ ESC Back │ Q Quit"+RST+TTBg) + "\n")
+	}
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+// runProfiles runs the profile screen until a profile is in use. At
+// startup there is no way back, only forward into a profile. Returns
+// "menu" or "quit".
+func (u *ui) runProfiles(keys <-chan keyEvent) string {
+	ps := &profileScreen{}
+	reload := func() {
+		ps.names, _ = u.profiles.list()
+		ps.sel = min(ps.sel, max(len(ps.names)-1, 0))
+	}
+	reload()
+	for i, n := range ps.names {
+		if u.profile != nil && n == u.profile.Name {
+			ps.sel = i
+		}
+	}
+	u.renderProfiles(ps, true)
+	for {
+		k := <-keys
+		switch k.kind {
+		case evNone:
+			continue
+		case evCtrlC:
+			return "quit"
+		}
+		ps.msg = ""
+		switch ps.mode {
+		case "new", "rename":
+			switch k.kind {
+			case evChar:
+				if len(ps.input) < profileNameMax {
+					ps.input = append(ps.input, []rune(k.text)...)
+				}
+			case evBackspace:
+				if len(ps.input) > 0 {
+					ps.input = ps.input[:len(ps.input)-1]
+				}
+			case evEscape:
+				ps.mode = ""
+			case evEnter:
+				name := string(ps.input)
+				var err error
+				if ps.mode == "new" {
+					var p *Profile
+					if p, err = u.profiles.create(name, defaultSettings); err == nil {
+						u.useProfile(p)
+						return "menu"
+					}
+				} else {
+					old := ps.names[ps.sel]
+					if err = u.profiles.rename(old, name); err == nil {
+						if u.profile != nil && u.profile.Name == old {
+							// keep the open profile pointing at its new home
+							u.profile = nil
+							var p *Profile
+							if p, err = u.profiles.open(name); err == nil {
+								u.useProfile(p)
+							} else if u.histPath == "" {
+								u.history = nil // its old home is gone
+							}
+						}
+						ps.mode = ""
+						reload()
+						for i, n := range ps.names {
+							if n == name {
+								ps.sel = i
+							}
+						}
+					}
+				}
+				if err != nil {
+					ps.msg = err.Error()
+				}
+			}
+			u.renderProfiles(ps, false)
+			continue
+		case "delete":
+			if k.kind == evChar && (k.ch == 'y' || k.ch == 'Y') {
+				if err := u.profiles.remove(ps.names[ps.sel]); err != nil {
+					ps.msg = err.Error()
+				}
+				reload()
+			}
+			ps.mode = ""
+			u.renderProfiles(ps, true)
+			continue
+		}
+
+		switch k.kind {
+		case evUp:
+			if ps.sel > 0 {
+				ps.sel--
+			}
+		case evDown:
+			if ps.sel < len(ps.names)-1 {
+				ps.sel++
+			}
+		case evEnter:
+			if ps.sel < len(ps.names) {
+				p, err := u.profiles.open(ps.names[ps.sel])
+				if err != nil {
+					ps.msg = err.Error()
+					break
+				}
+				u.useProfile(p)
+				return "menu"
+			}
+		case evEscape:
+			if u.profile != nil {
+				return "menu"
+			}
+		case evChar:
+			switch k.ch {
+			case 'n', 'N':
+				ps.mode, ps.input = "new", nil
+			case 'r', 'R':
+				if ps.sel < len(ps.names) {
+					ps.mode, ps.input = "rename", []rune(ps.names[ps.sel])
+				}
+			case 'd', 'D':
+				switch {
+				case ps.sel >= len(ps.names):
+				case u.profile != nil && ps.names[ps.sel] == u.profile.Name:
+					ps.msg = "Switch to another profile before deleting this one."
+				default:
+					ps.mode = "delete"
+				}
+			case 'q', 'Q':
+				return "quit"
+			}
+		}
+		u.renderProfiles(ps, ps.mode == "delete")
+	}
+}
+
+// startProfile picks the profile a console session starts with: the
+// -user one, the only one, or a new one named after the login. With
+// several to choose from it returns nil and the profile screen asks.
+func startProfile(ps *Profiles, user string) (*Profile, error) {
+	if user != "" {
+		return ps.openOrCreate(user, defaultSettings)
+	}
+	names, err := ps.list()
+	switch {
+	case err != nil:
+		return nil, err
+	case len(names) == 1:
+		return ps.open(names[0])
+	case len(names) == 0:
+		name := defaultName()
+		if validProfileName(name) != nil {
+			name = "me"
+		}
+		return ps.create(name, defaultSettings)
+	}
+	return nil, nil
+}
+
+// exportProfile is the profile `tt export` reads: -user, else the one
+// used last, else the only one.
+func exportProfile(ps *Profiles, user string) (*Profile, error) {
+	if user == "" {
+		user = ps.last()
+	}
+	if user == "" {
+		names, err := ps.list()
+		if err != nil {
+			return nil, err
+		}
+		if len(names) != 1 {
+			return nil, fmt.Errorf("%d profiles: pick one with -user", len(names))
+		}
+		user = names[0]
+	}
+	return ps.open(user)
+}
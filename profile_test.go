@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	os.MkdirAll(dataDir(), 0o755)
+	os.WriteFile(defaultHistoryPath(), []byte(`{"lesson":"old","cpm":100}`+"\n"), 0o644)
+
+	ps := newProfiles(defaultProfilesDir())
+	ann, err := ps.create("ann", Settings{Guide: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs, _ := newHistory(ann.historyPath()).load(); len(rs) != 1 || rs[0].Lesson != "old" {
+		t.Errorf("first profile did not take the old history: %v", rs)
+	}
+	if _, err := ps.create("ann", Settings{}); err == nil {
+		t.Error("duplicate profile created")
+	}
+	for _, bad := range []string{"", "../x", ".hidden", " ann", "a/b", "abcdefghijklmnopqrstu"} {
+		if _, err := ps.create(bad, Settings{}); err == nil {
+			t.Errorf("created profile %q", bad)
+		}
+	}
+	ps.create("bob", Settings{})
+	if err := ps.rename("bob", "bo"); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := ps.list(); !reflect.DeepEqual(names, []string{"ann", "bo"}) {
+		t.Errorf("list = %v", names)
+	}
+	if p, err := ps.open("ann"); err != nil || p.Settings.Guide != 2 {
+		t.Errorf("open ann = %+v, %v", p, err)
+	}
+	ps.remove("bo")
+	if ps.exists("bo") {
+		t.Error("bo not removed")
+	}
+}
+
+func TestProfileScoresAndSettings(t *testing.T) {
+	ps := newProfiles(t.TempDir())
+	ann, _ := ps.create("ann", Settings{})
+	bob, _ := ps.create("bob", Settings{})
+	u := &ui{term: newFakeTerm(), profiles: ps}
+	u.useProfile(ann)
+	u.settings.Curriculum = true
+
+	g := newWordStackGame(1)
+	g.score, g.gameOver = 300, true
+	u.noteScore("Word Stack", g)
+	g.score = 200
+	u.noteScore("Word Stack", g)
+	u.noteScore("", g)
+
+	u.useProfile(bob)
+	if u.settings.Curriculum || len(u.profile.HighScores) != 0 {
+		t.Errorf("bob got ann's state: %+v", u.profile)
+	}
+	ann, _ = ps.open("ann")
+	if !ann.Settings.Curriculum || !reflect.DeepEqual(ann.HighScores, map[string]int{"Word Stack": 300}) {
+		t.Errorf("ann = %+v", ann)
+	}
+	if got := ps.last(); got != "bob" {
+		t.Errorf("last profile = %q", got)
+	}
+	if p, err := exportProfile(ps, ""); err != nil || p.Name != "bob" {
+		t.Errorf("export profile = %v, %v", p, err)
+	}
+	if _, err := os.Stat(filepath.Join(ps.dir, "ann", "profile.json")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProfileSwitchKeepsHistoryFlag(t *testing.T) {
+	ps := newProfiles(t.TempDir())
+	ann, _ := ps.create("ann", Settings{})
+	bob, _ := ps.create("bob", Settings{})
+	path := filepath.Join(t.TempDir(), "class.jsonl")
+	u := &ui{term: newFakeTerm(), profiles: ps, histPath: path}
+	u.useProfile(ann)
+	u.useProfile(bob)
+	if u.history.path != path {
+		t.Errorf("after switching to bob the history is %s, want %s", u.history.path, path)
+	}
+	u.histPath = ""
+	u.useProfile(ann)
+	if u.history.path != ann.historyPath() {
+		t.Errorf("without -history ann's history is %s", u.history.path)
+	}
+}
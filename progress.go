@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Progress — speed and accuracy charts, practice time and streaks
+// ════════════════════════════════════════════════════════════════════
+
+const (
+	chartW    = 32 // chart columns; braille packs two samples per column
+	chartH    = 6  // chart rows; four dots each
+	barDays   = 14 // days in the practice-time bar chart
+	barH      = 4  // bar chart rows; eight steps each
+	barStride = 5  // columns per day in the bar chart
+)
+
+// brailleChart plots vals as a line w columns by h rows in braille
+// dots, scaled so lo sits on the bottom dot row and hi on the top one.
+// Only the newest 2·w values fit; fewer are stretched across the width.
+func brailleChart(vals []float64, w, h int, lo, hi float64) []string {
+	n := 2 * w
+	if len(vals) > n {
+		vals = vals[len(vals)-n:]
+	}
+	dots := make([][]bool, 4*h)
+	for i := range dots {
+		dots[i] = make([]bool, n)
+	}
+	level := func(v float64) int {
+		if hi <= lo {
+			return 0
+		}
+		y := int(math.Round((v - lo) / (hi - lo) * float64(4*h-1)))
+		return min(max(y, 0), 4*h-1)
+	}
+	// sample value at each dot column, interpolated between the points
+	cols := make([]float64, 0, n)
+	switch len(vals) {
+	case 0:
+	case 1:
+		cols = append(cols, vals[0])
+	default:
+		for x := 0; x < n; x++ {
+			f := float64(x) * float64(len(vals)-1) / float64(n-1)
+			i := min(int(f), len(vals)-2)
+			cols = append(cols, vals[i]+(vals[i+1]-vals[i])*(f-float64(i)))
+		}
+	}
+	for x, v := range cols {
+		y := level(v)
+		from := y
+		if x > 0 {
+			// join to the previous column so steep changes stay a line
+			from = level(cols[x-1])
+		}
+		for yy := min(from, y); yy <= max(from, y); yy++ {
+			if yy == y || yy != from {
+				dots[4*h-1-yy][x] = true
+			}
+		}
+	}
+	// braille dot bits by (row in cell, column in cell)
+	bits := [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+	rows := make([]string, h)
+	for r := 0; r < h; r++ {
+		var b strings.Builder
+		for c := 0; c < w; c++ {
+			cell := rune(0x2800)
+			for dy := 0; dy < 4; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					if dots[4*r+dy][2*c+dx] {
+						cell |= bits[dy][dx]
+					}
+				}
+			}
+			b.WriteRune(cell)
+		}
+		rows[r] = b.String()
+	}
+	return rows
+}
+
+// barChart draws one bar per value, h rows high in eighth blocks, each
+// bar two columns wide within stride columns.
+func barChart(vals []float64, h, stride int) []string {
+	top := 0.0
+	for _, v := range vals {
+		top = max(top, v)
+	}
+	blocks := []rune(" ▁▂▃▄▅▆▇█")
+	rows := make([]string, h)
+	for r := 0; r < h; r++ {
+		var b strings.Builder
+		for _, v := range vals {
+			eighths := 0
+			if top > 0 {
+				eighths = int(math.Round(v / top * float64(8*h)))
+				if v > 0 && eighths == 0 {
+					eighths = 1 // any practice shows
+				}
+			}
+			fill := min(max(eighths-8*(h-1-r), 0), 8)
+			b.WriteString(strings.Repeat(string(blocks[fill]), 2) + strings.Repeat(" ", stride-2))
+		}
+		rows[r] = b.String()
+	}
+	return rows
+}
+
+func day(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// dailySeconds returns the practice time of each of the n days ending
+// on today, oldest first.
+func dailySeconds(rs []Result, today time.Time, n int) []float64 {
+	out := make([]float64, n)
+	first := day(today).AddDate(0, 0, -(n - 1))
+	for _, r := range rs {
+		// whole days between the two dates, immune to DST shifts
+		i := int(math.Round(day(r.Time).Sub(first).Hours() / 24))
+		if i >= 0 && i < n {
+			out[i] += r.Seconds
+		}
+	}
+	return out
+}
+
+// streaks returns the run of consecutive practice days that reaches
+// today (or yesterday, when today has no practice yet) and the longest
+// run ever.
+func streaks(rs []Result, today time.Time) (current, longest int) {
+	days := map[time.Time]bool{}
+	for _, r := range rs {
+		days[day(r.Time)] = true
+	}
+	for d := range days {
+		if days[d.AddDate(0, 0, -1)] {
+			continue // not the first day of a run
+		}
+		n := 1
+		for days[d.AddDate(0, 0, n)] {
+			n++
+		}
+		longest = max(longest, n)
+	}
+	d := day(today)
+	if !days[d] {
+		d = d.AddDate(0, 0, -1)
+	}
+	for days[d] {
+		current++
+		d = d.AddDate(0, 0, -1)
+	}
+	return current, longest
+}
+
+// progressLessons lists "Overall" and then every lesson in the history,
+// in order of first practice.
+func progressLessons(rs []Result) []string {
+	names := []string{"Overall"}
+	seen := map[string]bool{}
+	for _, r := range rs {
+		if !seen[r.Lesson] {
+			seen[r.Lesson] = true
+			names = append(names, r.Lesson)
+		}
+	}
+	return names
+}
+
+func days(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+// fmtDuration shows a practice time as 1h05m, 12m or 40s.
+func fmtDuration(sec float64) string {
+	d := time.Duration(sec) * time.Second
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
+
+// renderProgress draws the progress screen for the sel-th entry of
+// progressLessons.
+func (u *ui) renderProgress(rs []Result, sel int, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	names := progressLessons(rs)
+	name := names[sel]
+	var picked []Result
+	for _, r := range rs {
+		if sel == 0 || r.Lesson == name {
+			picked = append(picked, r)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"TT — Progress"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+
+	var wpm, acc []float64
+	bestWPM := 0.0
+	for _, r := range picked {
+		wpm = append(wpm, r.WPM)
+		acc = append(acc, r.Accuracy)
+		bestWPM = max(bestWPM, r.WPM)
+	}
+	b.WriteString(hRow(fmt.Sprintf("%s◄ %s%s%s ►%s  Sessions: %s%d%s  Best: %s%.0f WPM%s",
+		TTDim, RST+TTBg+TTFg+BOLD, name, RST+TTBg+TTDim, RST+TTBg,
+		BOLD, len(picked), RST+TTBg, FgGrn+BOLD, bestWPM, RST+TTBg)) + "\n")
+
+	if len(picked) == 0 {
+		b.WriteString(hBlank() + "\n")
+		msg := "No finished lessons yet — complete one to start your charts."
+		if u.history == nil {
+			msg = "This session keeps no history."
+		}
+		b.WriteString(hCenter(TTDim+msg+RST+TTBg) + "\n")
+	} else {
+		// speed scales from zero to a round number above the best run,
+		// accuracy from a round number below the worst
+		wHi := math.Ceil(max(bestWPM, 1)/10) * 10
+		aLo := 100.0
+		for _, a := range acc {
+			aLo = min(aLo, a)
+		}
+		aLo = math.Min(math.Floor(aLo/10)*10, 90)
+		wc := brailleChart(wpm, chartW, chartH, 0, wHi)
+		ac := brailleChart(acc, chartW, chartH, aLo, 100)
+		b.WriteString(hRow(fmt.Sprintf("%s     WPM%s%s       Accuracy %%%s",
+			FgGrn, strings.Repeat(" ", chartW-3), FgCyn, RST+TTBg)) + "\n")
+		for i := 0; i < chartH; i++ {
+			wl, al := "    ", "    "
+			switch i {
+			case 0:
+				wl, al = fmt.Sprintf("%3.0f ", wHi), fmt.Sprintf("%3.0f ", 100.0)
+			case chartH - 1:
+				wl, al = fmt.Sprintf("%3.0f ", 0.0), fmt.Sprintf("%3.0f ", aLo)
+			}
+			b.WriteString(hRow(TTDim+wl+RST+TTBg+FgGrn+wc[i]+RST+TTBg+"    "+
+				TTDim+al+RST+TTBg+FgCyn+ac[i]+RST+TTBg) + "\n")
+		}
+		n := min(len(picked), 2*chartW)
+		b.WriteString(hRow(fmt.Sprintf("%s    last %d sessions, oldest to newest%s", TTDim, n, RST+TTBg)) + "\n")
+	}
+
+	b.WriteString(hMid() + "\n")
+	today := now()
+	daily := dailySeconds(rs, today, barDays)
+	b.WriteString(hRow(TTTitle+fmt.Sprintf("Practice time — last %d days", barDays)+RST+TTBg) + "\n")
+	for _, row := range barChart(daily, barH, barStride) {
+		b.WriteString(hRow("  "+FgYlw+row+RST+TTBg) + "\n")
+	}
+	var labels strings.Builder
+	for i := 0; i < barDays; i++ {
+		d := day(today).AddDate(0, 0, i-(barDays-1))
+		labels.WriteString(fmt.Sprintf("%-*d", barStride, d.Day()))
+	}
+	b.WriteString(hRow(TTDim+"  "+labels.String()+RST+TTBg) + "\n")
+	cur, long := streaks(rs, today)
+	total := 0.0
+	for _, r := range rs {
+		total += r.Seconds
+	}
+	b.WriteString(hRow(fmt.Sprintf("Streak: %s%s%s  Longest: %s%s%s  Today: %s%s%s  Total: %s%s%s",
+		FgGrn+BOLD, days(cur), RST+TTBg, BOLD, days(long), RST+TTBg,
+		FgYlw, fmtDuration(daily[barDays-1]), RST+TTBg, FgYlw, fmtDuration(total), RST+TTBg)) + "\n")
+	if u.histErr != nil {
+		b.WriteString(hRow(FgRed+truncate(u.histErrLine(), boxW-4)+RST+TTBg) + "\n")
+	}
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTDim+"Left/Right Lesson │ ESC Back │ Q Quit"+RST+TTBg) + "\n")
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
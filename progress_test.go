@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreaks(t *testing.T) {
+	today := time.Date(2024, 5, 20, 18, 0, 0, 0, time.Local)
+	at := func(daysAgo int) Result {
+		return Result{Time: today.AddDate(0, 0, -daysAgo).Add(-time.Hour), Seconds: 60}
+	}
+	cases := []struct {
+		ago           []int
+		current, long int
+	}{
+		{nil, 0, 0},
+		{[]int{0, 1, 2, 5, 6, 7, 8}, 3, 4},
+		{[]int{1, 2}, 2, 2}, // nothing today yet: yesterday's run still counts
+		{[]int{2, 3}, 0, 2},
+		{[]int{0, 0, 0}, 1, 1},
+	}
+	for _, c := range cases {
+		var rs []Result
+		for _, a := range c.ago {
+			rs = append(rs, at(a))
+		}
+		cur, long := streaks(rs, today)
+		if cur != c.current || long != c.long {
+			t.Errorf("%v: streaks = %d, %d; want %d, %d", c.ago, cur, long, c.current, c.long)
+		}
+	}
+}
+
+func TestDailySeconds(t *testing.T) {
+	today := time.Date(2024, 5, 20, 18, 0, 0, 0, time.Local)
+	rs := []Result{
+		{Time: today, Seconds: 30},
+		{Time: today.Add(-time.Hour), Seconds: 15},
+		{Time: today.AddDate(0, 0, -2), Seconds: 60},
+		{Time: today.AddDate(0, 0, -9), Seconds: 99}, // too old
+	}
+	got := dailySeconds(rs, today, 3)
+	if want := []float64{60, 0, 45}; got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("dailySeconds = %v, want %v", got, want)
+	}
+}
+
+func TestBrailleChart(t *testing.T) {
+	// a flat line at the bottom lights the lowest dot row of every cell
+	rows := brailleChart([]float64{0, 0}, 3, 2, 0, 10)
+	if rows[0] != "⠀⠀⠀" || rows[1] != "⣀⣀⣀" {
+		t.Errorf("flat line: %q", rows)
+	}
+	// a rise to the top is joined by a vertical stroke
+	rows = brailleChart([]float64{0, 10}, 1, 1, 0, 10)
+	if rows[0] != "⡸" {
+		t.Errorf("rise: %q", rows)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+// ════════════════════════════════════════════════════════════════════
+// Quote list — the bundled quotes and book excerpts
+// ════════════════════════════════════════════════════════════════════
+
+// quotes are bundled with tt; `tt quotes import` adds more.
+var quotes = []Quote{
+	// short
+	{"The only thing we have to fear is fear itself.", "Franklin D. Roosevelt", "First Inaugural Address, 1933"},
+	{"I think, therefore I am.", "René Descartes", "Discourse on the Method"},
+	{"The unexamined life is not worth living.", "Socrates", "Plato, Apology"},
+	{"Brevity is the soul of wit.", "William Shakespeare", "Hamlet"},
+	{"There is nothing either good or bad, but thinking makes it so.", "William Shakespeare", "Hamlet"},
+	{"All that glisters is not gold.", "William Shakespeare", "The Merchant of Venice"},
+	{"That's one small step for man, one giant leap for mankind.", "Neil Armstrong", "Apollo 11, 1969"},
+	{"Ask not what your country can do for you; ask what you can do for your country.", "John F. Kennedy", "Inaugural Address, 1961"},
+	{"The journey of a thousand miles begins with a single step.", "Lao Tzu", "Tao Te Ching"},
+	{"Whereof one cannot speak, thereof one must be silent.", "Ludwig Wittgenstein", "Tractatus Logico-Philosophicus"},
+	{"Man is born free, and everywhere he is in chains.", "Jean-Jacques Rousseau", "The Social Contract"},
+	{"Simplicity is prerequisite for reliability.", "Edsger W. Dijkstra", "EWD498"},
+	{"Premature optimization is the root of all evil.", "Donald Knuth", "Structured Programming with go to Statements"},
+	{"Beware of bugs in the above code; I have only proved it correct, not tried it.", "Donald Knuth", "letter to Peter van Emde Boas, 1977"},
+	{"Talk is cheap. Show me the code.", "Linus Torvalds", "linux-kernel mailing list, 2000"},
+	{"Clear is better than clever.", "Rob Pike", "Go Proverbs"},
+	{"Don't communicate by sharing memory; share memory by communicating.", "Rob Pike", "Go Proverbs"},
+	{"Programs must be written for people to read, and only incidentally for machines to execute.", "Harold Abelson and Gerald Jay Sussman", "Structure and Interpretation of Computer Programs"},
+	{"A language that doesn't affect the way you think about programming is not worth knowing.", "Alan Perlis", "Epigrams on Programming"},
+	{"Adding manpower to a late software project makes it later.", "Frederick P. Brooks", "The Mythical Man-Month"},
+	{"The best way to predict the future is to invent it.", "Alan Kay", ""},
+	{"Any sufficiently advanced technology is indistinguishable from magic.", "Arthur C. Clarke", "Profiles of the Future"},
+	{"If I have seen further it is by standing on the shoulders of Giants.", "Isaac Newton", "letter to Robert Hooke, 1675"},
+	{"Well done is better than well said.", "Benjamin Franklin", "Poor Richard's Almanack"},
+	{"Early to bed and early to rise, makes a man healthy, wealthy, and wise.", "Benjamin Franklin", "Poor Richard's Almanack"},
+	{"Things do not change; we change.", "Henry David Thoreau", "Walden"},
+	{"Life can only be understood backwards; but it must be lived forwards.", "Søren Kierkegaard", "Journals, 1843"},
+	{"Why, sometimes I've believed as many as six impossible things before breakfast.", "Lewis Carroll", "Through the Looking-Glass"},
+	{"Happy families are all alike; every unhappy family is unhappy in its own way.", "Leo Tolstoy", "Anna Karenina"},
+	{"Hope is the thing with feathers that perches in the soul.", "Emily Dickinson", ""},
+	{"You see, but you do not observe.", "Arthur Conan Doyle", "A Scandal in Bohemia"},
+	{"When you have eliminated the impossible, whatever remains, however improbable, must be the truth.", "Arthur Conan Doyle", "The Sign of the Four"},
+	{"We are all in the gutter, but some of us are looking at the stars.", "Oscar Wilde", "Lady Windermere's Fan"},
+	{"I am not afraid of storms, for I am learning how to sail my ship.", "Louisa May Alcott", "Little Women"},
+	{"Marley was dead: to begin with. There is no doubt whatever about that.", "Charles Dickens", "A Christmas Carol"},
+	{"The woods are lovely, dark and deep, but I have promises to keep, and miles to go before I sleep.", "Robert Frost", "Stopping by Woods on a Snowy Evening"},
+
+	// medium
+	{"Call me Ishmael. Some years ago, never mind how long precisely, having little or no money in my purse, and nothing particular to interest me on shore, I thought I would sail about a little and see the watery part of the world.", "Herman Melville", "Moby-Dick"},
+	{"We hold these truths to be self-evident, that all men are created equal, that they are endowed by their Creator with certain unalienable Rights, that among these are Life, Liberty and the pursuit of Happiness.", "Thomas Jefferson", "Declaration of Independence, 1776"},
+	{"Four score and seven years ago our fathers brought forth on this continent, a new nation, conceived in Liberty, and dedicated to the proposition that all men are created equal.", "Abraham Lincoln", "Gettysburg Address, 1863"},
+	{"There are two ways of constructing a software design: one way is to make it so simple that there are obviously no deficiencies, and the other way is to make it so complicated that there are no obvious deficiencies.", "C. A. R. Hoare", "The Emperor's Old Clothes, 1980"},
+	{"The reasonable man adapts himself to the world: the unreasonable one persists in trying to adapt the world to himself. Therefore all progress depends on the unreasonable man.", "George Bernard Shaw", "Man and Superman"},
+	{"Two roads diverged in a wood, and I, I took the one less traveled by, and that has made all the difference.", "Robert Frost", "The Road Not Taken"},
+	{"Whether I shall turn out to be the hero of my own life, or whether that station will be held by anybody else, these pages must show.", "Charles Dickens", "David Copperfield"},
+	{"In my younger and more vulnerable years my father gave me some advice that I've been turning over in my mind ever since.", "F. Scott Fitzgerald", "The Great Gatsby"},
+	{"Debugging is twice as hard as writing the code in the first place. Therefore, if you write the code as cleverly as possible, you are, by definition, not smart enough to debug it.", "Brian W. Kernighan", "The Elements of Programming Style"},
+	{"Hofstadter's Law: It always takes longer than you expect, even when you take into account Hofstadter's Law.", "Douglas Hofstadter", "Gödel, Escher, Bach"},
+	{"The question of whether a computer can think is no more interesting than the question of whether a submarine can swim.", "Edsger W. Dijkstra", "EWD898"},
+	{"The purpose of abstraction is not to be vague, but to create a new semantic level in which one can be absolutely precise.", "Edsger W. Dijkstra", "The Humble Programmer, 1972"},
+	{"A complex system that works is invariably found to have evolved from a simple system that worked. A complex system designed from scratch never works and cannot be patched up to make it work.", "John Gall", "Systemantics"},
+	{"Any organization that designs a system will produce a design whose structure is a copy of the organization's communication structure.", "Melvin E. Conway", "How Do Committees Invent?, 1968"},
+	{"A foolish consistency is the hobgoblin of little minds, adored by little statesmen and philosophers and divines.", "Ralph Waldo Emerson", "Self-Reliance"},
+	{"They who can give up essential liberty to obtain a little temporary safety deserve neither liberty nor safety.", "Benjamin Franklin", "Reply to the Governor, 1755"},
+	{"I am no bird; and no net ensnares me: I am a free human being with an independent will, which I now exert to leave you.", "Charlotte Brontë", "Jane Eyre"},
+	{"Because I could not stop for Death, He kindly stopped for me; The Carriage held but just Ourselves, And Immortality.", "Emily Dickinson", ""},
+	{"I celebrate myself, and sing myself, and what I assume you shall assume, for every atom belonging to me as good belongs to you.", "Walt Whitman", "Song of Myself"},
+	{"I propose to consider the question, 'Can machines think?' This should begin with definitions of the meaning of the terms 'machine' and 'think'.", "Alan Turing", "Computing Machinery and Intelligence, 1950"},
+
+	// long
+	{"It was the best of times, it was the worst of times, it was the age of wisdom, it was the age of foolishness, it was the epoch of belief, it was the epoch of incredulity, it was the season of Light, it was the season of Darkness, it was the spring of hope, it was the winter of despair, we had everything before us, we had nothing before us.", "Charles Dickens", "A Tale of Two Cities"},
+	{"It is a truth universally acknowledged, that a single man in possession of a good fortune, must be in want of a wife. However little known the feelings or views of such a man may be on his first entering a neighbourhood, this truth is so well fixed in the minds of the surrounding families, that he is considered the rightful property of some one or other of their daughters.", "Jane Austen", "Pride and Prejudice"},
+	{"Alice was beginning to get very tired of sitting by her sister on the bank, and of having nothing to do: once or twice she had peeped into the book her sister was reading, but it had no pictures or conversations in it, 'and what is the use of a book,' thought Alice, 'without pictures or conversations?'", "Lewis Carroll", "Alice's Adventures in Wonderland"},
+	{"To be, or not to be, that is the question: whether 'tis nobler in the mind to suffer the slings and arrows of outrageous fortune, or to take arms against a sea of troubles and by opposing end them. To die, to sleep, no more; and by a sleep to say we end the heart-ache and the thousand natural shocks that flesh is heir to.", "William Shakespeare", "Hamlet"},
+	{"It is not the critic who counts; not the man who points out how the strong man stumbles, or where the doer of deeds could have done them better. The credit belongs to the man who is actually in the arena, whose face is marred by dust and sweat and blood; who strives valiantly; who errs, who comes short again and again.", "Theodore Roosevelt", "Citizenship in a Republic, 1910"},
+	{"Now we are engaged in a great civil war, testing whether that nation, or any nation so conceived and so dedicated, can long endure. We are met on a great battle-field of that war. We have come to dedicate a portion of that field, as a final resting place for those who here gave their lives that that nation might live.", "Abraham Lincoln", "Gettysburg Address, 1863"},
+	{"I went to the woods because I wished to live deliberately, to front only the essential facts of life, and see if I could not learn what it had to teach, and not, when I came to die, discover that I had not lived. I did not wish to live what was not life, living is so dear; nor did I wish to practise resignation, unless it was quite necessary.", "Henry David Thoreau", "Walden"},
+	{"There is grandeur in this view of life, with its several powers, having been originally breathed into a few forms or into one; and that, whilst this planet has gone cycling on according to the fixed law of gravity, from so simple a beginning endless forms most beautiful and most wonderful have been, and are being, evolved.", "Charles Darwin", "On the Origin of Species"},
+	{"It was on a dreary night of November that I beheld the accomplishment of my toils. With an anxiety that almost amounted to agony, I collected the instruments of life around me, that I might infuse a spark of being into the lifeless thing that lay at my feet.", "Mary Shelley", "Frankenstein"},
+	{"If men were angels, no government would be necessary. If angels were to govern men, neither external nor internal controls on government would be necessary. In framing a government which is to be administered by men over men, the great difficulty lies in this: you must first enable the government to control the governed; and in the next place oblige it to control itself.", "James Madison", "The Federalist No. 51"},
+	{"The most merciful thing in the world, I think, is the inability of the human mind to correlate all its contents. We live on a placid island of ignorance in the midst of black seas of infinity, and it was not meant that we should voyage far.", "H. P. Lovecraft", "The Call of Cthulhu"},
+	{"Whenever I find myself growing grim about the mouth; whenever it is a damp, drizzly November in my soul; whenever I find myself involuntarily pausing before coffin warehouses, and bringing up the rear of every funeral I meet; then, I account it high time to get to sea as soon as I can.", "Herman Melville", "Moby-Dick"},
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Quotes — famous lines and book excerpts, with a personal best each
+// ════════════════════════════════════════════════════════════════════
+
+// Quote is a passage to type and who it is by.
+type Quote struct {
+	Text   string `json:"text"`
+	Author string `json:"author"`
+	Source string `json:"source,omitempty"`
+}
+
+// Quote lengths: under 100 characters, under 250, and longer.
+const (
+	quoteShort = iota
+	quoteMedium
+	quoteLong
+)
+
+var quoteLengths = []string{"Short", "Medium", "Long"}
+
+// length is the quoteShort, quoteMedium or quoteLong q counts as.
+func (q *Quote) length() int {
+	switch n := len([]rune(q.Text)); {
+	case n < 100:
+		return quoteShort
+	case n < 250:
+		return quoteMedium
+	}
+	return quoteLong
+}
+
+// lessonName names the lesson q is typed as. It is what the history
+// keeps a quote's results under, so it is the same on every run.
+func (q *Quote) lessonName() string {
+	return truncate("Quote — "+q.Author+": "+q.Text, 56)
+}
+
+// attribution is the author and, when known, the source.
+func (q *Quote) attribution() string {
+	if q.Source == "" {
+		return q.Author
+	}
+	return q.Author + ", " + q.Source
+}
+
+// lesson wraps q into lines that fit the typing screen.
+func (q *Quote) lesson() *Lesson {
+	l := &Lesson{Name: q.lessonName(), Quote: q}
+	var line string
+	for _, w := range strings.Fields(q.Text) {
+		if line != "" && vLen(line)+1+vLen(w) > genWidth {
+			l.Lines = append(l.Lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += w
+	}
+	l.Lines = append(l.Lines, line)
+	return l
+}
+
+// userQuotes are the quotes imported with `tt quotes import`.
+var userQuotes []Quote
+
+// quotesPath is where imported quotes are kept, for every profile.
+func quotesPath() string { return filepath.Join(dataDir(), "quotes.json") }
+
+// loadUserQuotes reads the imported quotes; having none is no error.
+func loadUserQuotes(path string) ([]Quote, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var qs []Quote
+	if err := json.Unmarshal(data, &qs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return qs, nil
+}
+
+// allQuotes are the bundled quotes and the imported ones.
+func allQuotes() []Quote {
+	return append(append([]Quote(nil), quotes...), userQuotes...)
+}
+
+// quoteLessons are the lessons of the quotes track, one per length.
+// Each deals a random quote of its length.
+func quoteLessons() []Lesson {
+	var out []Lesson
+	for n, name := range quoteLengths {
+		out = append(out, Lesson{
+			Name: name + " quotes",
+			next: func(*Settings) *Lesson {
+				var qs []Quote
+				for _, q := range allQuotes() {
+					if q.length() == n {
+						qs = append(qs, q)
+					}
+				}
+				q := qs[rand.New(rand.NewSource(gameSeed())).Intn(len(qs))]
+				return q.lesson()
+			},
+		})
+	}
+	return out
+}
+
+// personalBest is the best speed of earlier runs of the lesson called
+// name, leaving out the run just finished.
+func (u *ui) personalBest(name string) (float64, bool) {
+	rs := u.results
+	if u.last != nil && len(rs) > 0 {
+		rs = rs[:len(rs)-1]
+	}
+	best, ok := 0.0, false
+	for _, r := range rs {
+		if r.Lesson == name && r.CPM > best {
+			best, ok = r.CPM, true
+		}
+	}
+	return best, ok
+}
+
+// bestLine is the results screen's comparison with the personal best.
+func (u *ui) bestLine(name string, cpm float64) string {
+	best, ok := u.personalBest(name)
+	switch {
+	case !ok:
+		return fmt.Sprintf("Best:     %sfirst run — %.0f CPM to beat next time%s", TTDim, cpm, RST+TTBg)
+	case cpm > best:
+		return fmt.Sprintf("Best:     %s★ New personal best!%s (was %.0f CPM)", FgYlw+BOLD, RST+TTBg, best)
+	}
+	return fmt.Sprintf("Best:     %s%.0f CPM%s", TTFg+BOLD, best, RST+TTBg)
+}
+
+// quotesNote is the quotes track's line on how many quotes there are
+// and how many have a personal best.
+func (u *ui) quotesNote() string {
+	names := map[string]bool{}
+	for _, r := range u.results {
+		names[r.Lesson] = true
+	}
+	qs := allQuotes()
+	done := 0
+	for i := range qs {
+		if names[qs[i].lessonName()] {
+			done++
+		}
+	}
+	return fmt.Sprintf("%s%d quotes, %d typed │ add your own with tt quotes import%s", TTDim, len(qs), done, RST+TTBg)
+}
+
+// parseQuotes reads a quote collection: a JSON array of quotes, or
+// plain text with a blank line between quotes, each ending in a line
+// "-- Author, Source".
+func parseQuotes(data []byte) ([]Quote, error) {
+	if s := strings.TrimSpace(string(data)); strings.HasPrefix(s, "[") {
+		var qs []Quote
+		if err := json.Unmarshal(data, &qs); err != nil {
+			return nil, err
+		}
+		for i, q := range qs {
+			if strings.TrimSpace(q.Text) == "" || q.Author == "" {
+				return nil, fmt.Errorf("quote %d needs a text and an author", i+1)
+			}
+		}
+		return qs, nil
+	}
+	var qs []Quote
+	for i, block := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		if len(lines) == 1 && lines[0] == "" {
+			continue
+		}
+		last := strings.TrimSpace(lines[len(lines)-1])
+		by, ok := strings.CutPrefix(last, "--")
+		if !ok {
+			by, ok = strings.CutPrefix(last, "—")
+		}
+		if !ok || len(lines) < 2 {
+			return nil, fmt.Errorf("quote %d does not end in a line \"-- Author\"", i+1)
+		}
+		q := Quote{Text: strings.Join(strings.Fields(strings.Join(lines[:len(lines)-1], " ")), " ")}
+		q.Author, q.Source, _ = strings.Cut(strings.TrimSpace(by), ", ")
+		qs = append(qs, q)
+	}
+	return qs, nil
+}
+
+// quotesCmd runs `tt quotes`; args are the words after "quotes". With
+// no words it counts the quotes of each length; `import` adds files to
+// the imported quotes, which main has loaded.
+func quotesCmd(args []string) error {
+	if len(args) == 0 {
+		counts := make([]int, len(quoteLengths))
+		for _, q := range allQuotes() {
+			counts[q.length()]++
+		}
+		for n, name := range quoteLengths {
+			fmt.Printf("%-7s %d\n", name, counts[n])
+		}
+		fmt.Printf("%d imported, kept in %s\n", len(userQuotes), quotesPath())
+		return nil
+	}
+	if args[0] != "import" || len(args) < 2 {
+		return errors.New("usage: tt quotes [import file...]")
+	}
+	have := map[string]bool{}
+	for _, q := range allQuotes() {
+		have[q.Text] = true
+	}
+	added, total := 0, 0
+	for _, path := range args[1:] {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		in, err := parseQuotes(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		total += len(in)
+		for _, q := range in {
+			if !have[q.Text] {
+				have[q.Text] = true
+				userQuotes = append(userQuotes, q)
+				added++
+			}
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(quotesPath()), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(userQuotes, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(quotesPath(), data, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("imported %d quotes (%d already there)\n", added, total-added)
+	return nil
+}
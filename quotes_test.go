@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuotesWrapAndCoverEveryLength(t *testing.T) {
+	counts := make([]int, len(quoteLengths))
+	names := map[string]bool{}
+	for i := range quotes {
+		q := &quotes[i]
+		counts[q.length()]++
+		l := q.lesson()
+		for _, line := range l.Lines {
+			if vLen(line) > genWidth {
+				t.Errorf("%q is %d columns, want at most %d", line, vLen(line), genWidth)
+			}
+		}
+		if got := strings.Join(l.Lines, " "); got != q.Text {
+			t.Errorf("wrapped %q, want %q", got, q.Text)
+		}
+		if names[l.Name] {
+			t.Errorf("two quotes are typed as %q", l.Name)
+		}
+		names[l.Name] = true
+	}
+	for n, c := range counts {
+		if c < 10 {
+			t.Errorf("%d %s quotes, want at least 10", c, quoteLengths[n])
+		}
+	}
+}
+
+func TestQuoteLessonsDealTheirLength(t *testing.T) {
+	st := &Settings{}
+	for n, stub := range quoteLessons() {
+		l := st.practiceLesson(&stub)
+		if l.Quote == nil || l.Quote.length() != n || l.next == nil {
+			t.Errorf("%s dealt %+v", stub.Name, l.Quote)
+		}
+	}
+}
+
+func TestPersonalBest(t *testing.T) {
+	u := &ui{}
+	name := quotes[0].lessonName()
+	if !strings.Contains(u.bestLine(name, 200), "first run") {
+		t.Error("no first run note without earlier results")
+	}
+	u.results = []Result{{Lesson: name, CPM: 250}, {Lesson: "other", CPM: 900}, {Lesson: name, CPM: 300}}
+	u.last = &u.results[2]
+	if best, ok := u.personalBest(name); !ok || best != 250 {
+		t.Errorf("personal best %v, %v; want 250 from before this run", best, ok)
+	}
+	if !strings.Contains(u.bestLine(name, 300), "New personal best") {
+		t.Error("300 CPM over a best of 250 is not a new personal best")
+	}
+}
+
+func TestParseQuotes(t *testing.T) {
+	qs, err := parseQuotes([]byte("Stay hungry,\nstay foolish.\n-- Whole Earth Catalog, 1974\n\nShort.\n— Anon\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Quote{{"Stay hungry, stay foolish.", "Whole Earth Catalog", "1974"}, {"Short.", "Anon", ""}}
+	if len(qs) != 2 || qs[0] != want[0] || qs[1] != want[1] {
+		t.Errorf("parsed %+v, want %+v", qs, want)
+	}
+	if _, err := parseQuotes([]byte("no attribution here\n")); err == nil {
+		t.Error("parsed a quote without an author")
+	}
+	qs, err = parseQuotes([]byte(`[{"text": "Hi.", "author": "Me"}]`))
+	if err != nil || len(qs) != 1 || qs[0].Author != "Me" {
+		t.Errorf("parsed JSON %+v, %v", qs, err)
+	}
+	if _, err := parseQuotes([]byte(`[{"text": "Hi."}]`)); err == nil {
+		t.Error("parsed a JSON quote without an author")
+	}
+}
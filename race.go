@@ -0,0 +1,751 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// LAN race — `tt host` / `tt join <addr>` over plain TCP
+// ════════════════════════════════════════════════════════════════════
+
+// raceDefaultPort is used when `tt host` or `tt join` gets no port.
+const raceDefaultPort = "7777"
+
+// raceMaxPlayers is as many players, the host included, as the lobby,
+// race and ranking screens have rows for; later joins are turned away.
+const raceMaxPlayers = 12
+
+// racePlayer is one participant's standing as the host sees it.
+type racePlayer struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Typed  int     `json:"typed"`  // clusters typed over the whole lesson
+	Total  int     `json:"total"`  // clusters in the lesson
+	Errors int     `json:"errors"` // wrong clusters over the whole lesson
+	CPM    float64 `json:"cpm"`
+	Done   bool    `json:"done"`
+	Place  int     `json:"place,omitempty"` // finishing position, 0 until done
+	Left   bool    `json:"left,omitempty"`  // disconnected
+}
+
+// netMsg is the wire format: one JSON object per line. Clients send
+// "hello" and "progress"; the host answers "welcome" and broadcasts
+// "players" (lobby and live standings), "start" and "end".
+type netMsg struct {
+	Type    string       `json:"type"`
+	Name    string       `json:"name,omitempty"`
+	ID      int          `json:"id,omitempty"`
+	Text    string       `json:"text,omitempty"` // "error" reason
+	Lesson  *Lesson      `json:"lesson,omitempty"`
+	Player  *racePlayer  `json:"player,omitempty"`
+	Players []racePlayer `json:"players,omitempty"`
+}
+
+// raceLink is one participant's end of a race, on the host or a client.
+type raceLink interface {
+	self() int
+	// messages delivers everything the host broadcasts, plus a
+	// "closed" message when the connection drops.
+	messages() <-chan netMsg
+	report(p racePlayer)
+	close()
+}
+
+// deliver queues m for the UI. Standings are full snapshots, so a
+// "players" message is dropped when the UI is behind, keeping half the
+// queue free for the messages that must arrive.
+func deliver(ch chan netMsg, m netMsg) {
+	if m.Type == "players" && len(ch) >= cap(ch)/2 {
+		return
+	}
+	ch <- m
+}
+
+// lessonClusters counts the grapheme clusters of every line of l.
+func lessonClusters(l *Lesson) int {
+	n := 0
+	for _, line := range l.Lines {
+		n += len(lineClusters(line))
+	}
+	return n
+}
+
+// ranking orders players by finishing place, then by progress.
+func ranking(ps []racePlayer) []racePlayer {
+	out := append([]racePlayer(nil), ps...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		switch {
+		case a.Place != 0 && b.Place != 0:
+			return a.Place < b.Place
+		case a.Place != 0 || b.Place != 0:
+			return a.Place != 0
+		}
+		return a.Typed > b.Typed
+	})
+	return out
+}
+
+func ordinal(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return fmt.Sprintf("%dth", n)
+	case n%10 == 1:
+		return fmt.Sprintf("%dst", n)
+	case n%10 == 2:
+		return fmt.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return fmt.Sprintf("%drd", n)
+	}
+	return fmt.Sprintf("%dth", n)
+}
+
+// withPort appends the default race port to addr when it has none.
+func withPort(addr string) string { return withDefaultPort(addr, raceDefaultPort) }
+
+// withDefaultPort appends port to addr when it has none.
+func withDefaultPort(addr, port string) string {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return net.JoinHostPort(addr, port)
+	}
+	return addr
+}
+
+// ── Host ─────────────────────────────────────────────────────────────
+
+// peer is a connected client.
+type peer struct {
+	conn net.Conn
+	enc  *json.Encoder
+}
+
+// raceHost accepts clients, keeps the authoritative standings and
+// takes part in the race itself as player 1.
+type raceHost struct {
+	ln      net.Listener
+	mu      sync.Mutex
+	players []racePlayer
+	peers   map[int]*peer
+	lesson  *Lesson
+	started bool
+	places  int // players finished so far
+	nextID  int
+	local   chan netMsg
+}
+
+func newRaceHost(addr, name string) (*raceHost, error) {
+	ln, err := net.Listen("tcp", withPort(addr))
+	if err != nil {
+		return nil, err
+	}
+	h := &raceHost{
+		ln:      ln,
+		players: []racePlayer{{ID: 1, Name: name}},
+		peers:   map[int]*peer{},
+		nextID:  2,
+		local:   make(chan netMsg, 64),
+	}
+	go h.acceptLoop()
+	return h, nil
+}
+
+func (h *raceHost) self() int               { return 1 }
+func (h *raceHost) messages() <-chan netMsg { return h.local }
+func (h *raceHost) report(p racePlayer)     { h.progress(1, p) }
+func (h *raceHost) addr() string            { return h.ln.Addr().String() }
+
+// snapshot copies the standings. The caller holds h.mu.
+func (h *raceHost) snapshot() []racePlayer { return append([]racePlayer(nil), h.players...) }
+
+// standings copies the standings under the lock.
+func (h *raceHost) standings() []racePlayer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.snapshot()
+}
+
+func (h *raceHost) close() {
+	h.ln.Close()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, p := range h.peers {
+		p.conn.Close()
+	}
+}
+
+func (h *raceHost) acceptLoop() {
+	for {
+		conn, err := h.ln.Accept()
+		if err != nil {
+			return
+		}
+		go h.serve(conn)
+	}
+}
+
+// serve runs one client connection: the hello handshake, then
+// progress reports until the client goes away.
+func (h *raceHost) serve(conn net.Conn) {
+	defer conn.Close()
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	p := &peer{conn: conn, enc: json.NewEncoder(conn)}
+
+	var hello netMsg
+	if err := dec.Decode(&hello); err != nil || hello.Type != "hello" {
+		return
+	}
+	h.mu.Lock()
+	if h.started {
+		h.mu.Unlock()
+		p.enc.Encode(netMsg{Type: "error", Text: "a race is in progress"})
+		return
+	}
+	if h.playing() >= raceMaxPlayers {
+		h.mu.Unlock()
+		p.enc.Encode(netMsg{Type: "error", Text: fmt.Sprintf("the race is full (%d players)", raceMaxPlayers)})
+		return
+	}
+	id := h.nextID
+	h.nextID++
+	h.peers[id] = p
+	h.players = append(h.players, racePlayer{ID: id, Name: hello.Name})
+	h.send(p, netMsg{Type: "welcome", ID: id})
+	h.broadcast(netMsg{Type: "players", Players: h.snapshot()})
+	h.mu.Unlock()
+
+	for {
+		var m netMsg
+		if err := dec.Decode(&m); err != nil {
+			break
+		}
+		if m.Type == "progress" && m.Player != nil {
+			h.progress(id, *m.Player)
+		}
+	}
+
+	h.mu.Lock()
+	delete(h.peers, id)
+	ps := h.players[:0]
+	for _, pl := range h.players {
+		switch {
+		case pl.ID != id:
+		case h.started:
+			pl.Left = true // keep their standing in the ranking
+		default:
+			continue // gone from the lobby
+		}
+		ps = append(ps, pl)
+	}
+	h.players = ps
+	h.broadcast(netMsg{Type: "players", Players: h.snapshot()})
+	h.checkEnd()
+	h.mu.Unlock()
+}
+
+// playing counts the players still connected. The caller holds h.mu.
+func (h *raceHost) playing() int {
+	n := 0
+	for _, p := range h.players {
+		if !p.Left {
+			n++
+		}
+	}
+	return n
+}
+
+// send writes m to one client; a client too slow to take it within a
+// couple of seconds is dropped rather than stalling the race.
+func (h *raceHost) send(p *peer, m netMsg) {
+	p.conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
+	if err := p.enc.Encode(m); err != nil {
+		p.conn.Close()
+	}
+}
+
+// broadcast sends m to every client and to the host's own UI.
+// The caller holds h.mu.
+func (h *raceHost) broadcast(m netMsg) {
+	for _, p := range h.peers {
+		h.send(p, m)
+	}
+	deliver(h.local, m)
+}
+
+// start begins a race on l for everyone connected.
+func (h *raceHost) start(l *Lesson) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lesson, h.started, h.places = l, true, 0
+	var ps []racePlayer
+	for _, p := range h.players {
+		if !p.Left {
+			ps = append(ps, racePlayer{ID: p.ID, Name: p.Name, Total: lessonClusters(l)})
+		}
+	}
+	h.players = ps
+	h.broadcast(netMsg{Type: "start", Lesson: l, Players: h.snapshot()})
+}
+
+// stop ends the race early with the standings as they are.
+func (h *raceHost) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.started {
+		h.started = false
+		h.broadcast(netMsg{Type: "end", Players: ranking(h.players)})
+	}
+}
+
+// progress records a participant's report. The host owns identity and
+// placing; everything else is taken from the report.
+func (h *raceHost) progress(id int, r racePlayer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.started {
+		return
+	}
+	for i := range h.players {
+		p := &h.players[i]
+		if p.ID != id || p.Done {
+			continue
+		}
+		p.Typed, p.Errors, p.CPM, p.Done = min(r.Typed, p.Total), r.Errors, r.CPM, r.Done
+		if p.Done {
+			h.places++
+			p.Place = h.places
+		}
+	}
+	h.broadcast(netMsg{Type: "players", Players: h.snapshot()})
+	h.checkEnd()
+}
+
+// checkEnd finishes the race once every connected player is done.
+// The caller holds h.mu.
+func (h *raceHost) checkEnd() {
+	if !h.started {
+		return
+	}
+	for _, p := range h.players {
+		if !p.Left && !p.Done {
+			return
+		}
+	}
+	h.started = false
+	h.broadcast(netMsg{Type: "end", Players: ranking(h.players)})
+}
+
+// ── Client ───────────────────────────────────────────────────────────
+
+// raceClient is a joined participant's connection to the host.
+type raceClient struct {
+	conn net.Conn
+	enc  *json.Encoder
+	mu   sync.Mutex
+	id   int
+	msgs chan netMsg
+}
+
+// dialRace connects to a race host and completes the hello handshake.
+func dialRace(addr, name string) (*raceClient, error) {
+	conn, err := net.DialTimeout("tcp", withPort(addr), 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	c := &raceClient{conn: conn, enc: json.NewEncoder(conn), msgs: make(chan netMsg, 64)}
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	if err := c.enc.Encode(netMsg{Type: "hello", Name: name}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var m netMsg
+	if err := dec.Decode(&m); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetReadDeadline(time.Time{})
+	switch m.Type {
+	case "welcome":
+		c.id = m.ID
+	case "error":
+		conn.Close()
+		return nil, errors.New(m.Text)
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("unexpected %q from host", m.Type)
+	}
+	go func() {
+		for {
+			var m netMsg
+			if err := dec.Decode(&m); err != nil {
+				deliver(c.msgs, netMsg{Type: "closed"})
+				return
+			}
+			deliver(c.msgs, m)
+		}
+	}()
+	return c, nil
+}
+
+func (c *raceClient) self() int               { return c.id }
+func (c *raceClient) messages() <-chan netMsg { return c.msgs }
+func (c *raceClient) close()                  { c.conn.Close() }
+
+func (c *raceClient) report(p racePlayer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enc.Encode(netMsg{Type: "progress", Player: &p})
+}
+
+// ════════════════════════════════════════════════════════════════════
+// Race screens
+// ════════════════════════════════════════════════════════════════════
+
+// raceProgress reports the session's standing over the whole lesson.
+func raceProgress(s *Session, start time.Time) racePlayer {
+	p := racePlayer{Done: s.allDone()}
+	for i := 0; i < s.lineIdx; i++ {
+		p.Typed += len(lineClusters(s.lesson.Lines[i]))
+	}
+	p.Typed += len(s.typed)
+	for _, st := range s.lineStats {
+		p.Errors += st.Errors
+	}
+	if len(s.lineStats) <= s.lineIdx {
+		p.Errors += s.errors // the current line is still open
+	}
+	if m := time.Since(start).Minutes(); m > 0 {
+		p.CPM = float64(p.Typed) / m
+	}
+	return p
+}
+
+// raceBars renders one progress row per player, the local one marked.
+func raceBars(ps []racePlayer, self int) []string {
+	var rows []string
+	for _, p := range ps {
+		const barW = 30
+		n := 0
+		if p.Total > 0 {
+			n = p.Typed * barW / p.Total
+		}
+		name := p.Name
+		if vLen(name) > 12 {
+			name = string([]rune(name)[:12])
+		}
+		name += strings.Repeat(" ", 12-vLen(name))
+		color := TTFg
+		if p.ID == self {
+			color = FgCyn + BOLD
+		}
+		status := ""
+		switch {
+		case p.Left:
+			status = FgGry + "left" + RST + TTBg
+		case p.Done:
+			status = FgGrn + "✓ " + ordinal(p.Place) + RST + TTBg
+		}
+		rows = append(rows, fmt.Sprintf("%s%s%s [%s%s%s%s%s] %s%4.0f%s CPM %s%3d%s err %s",
+			color, name, RST+TTBg,
+			FgGrn, strings.Repeat("█", n), FgGry, strings.Repeat("░", barW-n), RST+TTBg,
+			FgGrn, p.CPM, RST+TTBg, FgRed, p.Errors, RST+TTBg, status))
+	}
+	return rows
+}
+
+// renderRaceLobby shows who has joined, next to the lessons the host
+// picks the race from.
+func (u *ui) renderRaceLobby(info string, ps []racePlayer, self int, host bool, sel int, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"TT — Network Race"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTDim+info+RST+TTBg) + "\n")
+	b.WriteString(hBlank() + "\n")
+
+	// lessons on the left for the host, players on the right
+	header := ""
+	if host {
+		header = TTTitle + "Race Lesson:" + RST + TTBg
+	}
+	header += strings.Repeat(" ", menuColW-vLen(header)) + TTTitle + fmt.Sprintf("Players (%d):", len(ps)) + RST + TTBg
+	b.WriteString(hRow(header) + "\n")
+	rows := len(ps)
+	if host {
+		rows = max(rows, len(lessons))
+	}
+	for i := 0; i < rows; i++ {
+		left := strings.Repeat(" ", menuColW)
+		if host && i < len(lessons) {
+			left = menuCell(i, sel, lessons[i].Name)
+		}
+		right := ""
+		if i < len(ps) {
+			right = "  " + ps[i].Name
+			if ps[i].ID == self {
+				right = FgCyn + "▸ " + BOLD + ps[i].Name + RST + TTBg
+			}
+		}
+		b.WriteString(hRow(left+right) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	if host {
+		b.WriteString(hRow(TTDim+"Up/Down Select │ Enter Start Race │ Q Quit"+RST+TTBg) + "\n")
+	} else {
+		b.WriteString(hRow(TTDim+"Waiting for the host to start the race... │ Q Quit"+RST+TTBg) + "\n")
+	}
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+// renderRace shows the local typing line above everyone's progress.
+func (u *ui) renderRace(s *Session, ps []racePlayer, self int, first bool) {
+	if first {
+		u.cls()
+	} else {
+		u.home()
+	}
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"TT — Network Race"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(fmt.Sprintf("%s%s%s    Line %d/%d", BOLD+TTFg, s.lesson.Name, RST+TTBg, s.lineIdx+1, len(s.lesson.Lines))) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hBlank() + "\n")
+	if s.allDone() {
+		b.WriteString(hCenter(BOLD+TTHilite+"✓ Finished! Waiting for the others..."+RST+TTBg) + "\n")
+		b.WriteString(hBlank() + "\n")
+	} else {
+		var typed strings.Builder
+		for i := range s.target {
+			typed.WriteString(inputCell(s, i))
+		}
+		b.WriteString(hRow(FgWht+BOLD+"Target: "+RST+TTBg+FgWht+strings.Join(s.target, "")+RST+TTBg) + "\n")
+		b.WriteString(hRow(FgWht+BOLD+"Input:  "+RST+TTBg+typed.String()+RST+TTBg) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	for _, row := range raceBars(ps, self) {
+		b.WriteString(hRow(row) + "\n")
+	}
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTDim+"Backspace=Delete │ ESC=Leave Race │ Ctrl-C=Quit"+RST+TTBg) + "\n")
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+// renderRaceRanking shows the final standings.
+func (u *ui) renderRaceRanking(l *Lesson, ps []racePlayer, self int, host bool) {
+	u.cls()
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"TT — Race Results"+RST+TTBg) + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(fmt.Sprintf("Lesson:   %s%s%s", TTFg+BOLD, l.Name, RST+TTBg)) + "\n")
+	b.WriteString(hBlank() + "\n")
+	for i, p := range ps {
+		color := TTFg
+		if p.ID == self {
+			color = FgCyn + BOLD
+		}
+		place := ordinal(i + 1)
+		if p.Place == 0 {
+			place = "DNF" // did not finish
+		}
+		b.WriteString(hRow(fmt.Sprintf("%s%-5s %-14s%s %s%4.0f CPM%s  %s%3d errors%s",
+			color, place, p.Name, RST+TTBg, FgGrn, p.CPM, RST+TTBg, FgRed, p.Errors, RST+TTBg)) + "\n")
+	}
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	if host {
+		b.WriteString(hRow(TTDim+"Enter=New Race │ Q=Quit"+RST+TTBg) + "\n")
+	} else {
+		b.WriteString(hRow(TTDim+"Waiting for the host's next race... │ Q=Quit"+RST+TTBg) + "\n")
+	}
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
+
+// Race screens.
+const (
+	rsLobby = iota
+	rsRacing
+	rsRanking
+)
+
+// runRace drives one participant's screens until they quit. info is
+// the lobby's status line, e.g. the address to join.
+func (u *ui) runRace(keys <-chan keyEvent, link raceLink, info string) error {
+	host, isHost := link.(*raceHost)
+	self := link.self()
+	var players []racePlayer
+	if isHost {
+		players = host.standings()
+	}
+	state := rsLobby
+	sel := 0
+	var sess *Session
+	var start time.Time
+
+	u.renderRaceLobby(info, players, self, isHost, sel, true)
+	for {
+		select {
+		case m := <-link.messages():
+			switch m.Type {
+			case "players":
+				players = m.Players
+				switch state {
+				case rsLobby:
+					u.renderRaceLobby(info, players, self, isHost, sel, false)
+				case rsRacing:
+					u.renderRace(sess, players, self, false)
+				}
+			case "start":
+				players = m.Players
+				sess = newSession(m.Lesson)
+				start = time.Now()
+				state = rsRacing
+				u.renderRace(sess, players, self, true)
+			case "end":
+				if sess == nil {
+					continue
+				}
+				players = m.Players
+				state = rsRanking
+				u.renderRaceRanking(sess.lesson, players, self, isHost)
+			case "closed":
+				return errors.New("connection to the race host was lost")
+			}
+
+		case k := <-keys:
+			if k.kind == evCtrlC {
+				return nil
+			}
+			switch state {
+			case rsLobby, rsRanking:
+				switch {
+				case k.kind == evChar && (k.ch == 'q' || k.ch == 'Q'), k.kind == evEscape:
+					return nil
+				case !isHost:
+				case state == rsRanking && k.kind == evEnter:
+					state = rsLobby
+					players = host.standings()
+					u.renderRaceLobby(info, players, self, true, sel, true)
+				case state == rsLobby && k.kind == evUp && sel > 0:
+					sel--
+					u.renderRaceLobby(info, players, self, true, sel, false)
+				case state == rsLobby && k.kind == evDown && sel < len(lessons)-1:
+					sel++
+					u.renderRaceLobby(info, players, self, true, sel, false)
+				case state == rsLobby && k.kind == evEnter:
+					host.start(&lessons[sel])
+				}
+
+			case rsRacing:
+				switch k.kind {
+				case evEscape:
+					if isHost {
+						host.stop()
+						continue
+					}
+					return nil
+				case evBackspace:
+					if !sess.allDone() {
+						sess.backspace()
+						link.report(raceProgress(sess, start))
+					}
+				case evChar:
+					for _, r := range u.settings.remapKey(k).text {
+						if sess.allDone() {
+							break
+						}
+						if sess.addRune(r) {
+							u.bell()
+						}
+						if sess.lineFinished() {
+							// races run straight on to the next line
+							sess.finishLine()
+							sess.advanceLine()
+						}
+					}
+					link.report(raceProgress(sess, start))
+				}
+				u.renderRace(sess, players, self, false)
+			}
+		}
+	}
+}
+
+// defaultName is the -name default: the login name, else the host name.
+func defaultName() string {
+	if u := os.Getenv("USER"); u != "" {
+		return u
+	}
+	if h, err := os.Hostname(); err == nil {
+		return h
+	}
+	return "player"
+}
+
+// localIP guesses the LAN address others can join, for the lobby.
+func localIP() string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "localhost"
+	}
+	for _, a := range addrs {
+		if ip, ok := a.(*net.IPNet); ok && !ip.IP.IsLoopback() && ip.IP.To4() != nil {
+			return ip.IP.String()
+		}
+	}
+	return "localhost"
+}
+
+// hostRace runs `tt host [addr]`.
+func hostRace(addr, name string) error {
+	if addr == "" {
+		addr = ":" + raceDefaultPort
+	}
+	h, err := newRaceHost(addr, name)
+	if err != nil {
+		return err
+	}
+	defer h.close()
+	_, port, _ := net.SplitHostPort(h.addr())
+	info := fmt.Sprintf("Hosting — others run: tt join %s:%s", localIP(), port)
+	return withConsole(func(u *ui, keys <-chan keyEvent) error {
+		defer u.goodbye()
+		return u.runRace(keys, h, info)
+	})
+}
+
+// joinRace runs `tt join <addr>`.
+func joinRace(addr, name string) error {
+	c, err := dialRace(addr, name)
+	if err != nil {
+		return err
+	}
+	defer c.close()
+	info := fmt.Sprintf("Joined %s as %s", withPort(addr), name)
+	return withConsole(func(u *ui, keys <-chan keyEvent) error {
+		defer u.goodbye()
+		return u.runRace(keys, c, info)
+	})
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// waitFor reads messages from link until one of type typ arrives.
+func waitFor(t *testing.T, link raceLink, typ string) netMsg {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case m := <-link.messages():
+			if m.Type == typ {
+				return m
+			}
+		case <-timeout:
+			t.Fatalf("no %q message", typ)
+		}
+	}
+}
+
+func TestRaceLoopback(t *testing.T) {
+	h, err := newRaceHost("127.0.0.1:0", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.close()
+	c, err := dialRace(h.addr(), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.close()
+	if c.self() != 2 {
+		t.Fatalf("client id = %d, want 2", c.self())
+	}
+	if m := waitFor(t, c, "players"); len(m.Players) != 2 || m.Players[1].Name != "alice" {
+		t.Fatalf("lobby = %+v", m.Players)
+	}
+
+	l := &Lesson{Name: "race", Lines: []string{"ab cd", "ef"}}
+	h.start(l)
+	m := waitFor(t, c, "start")
+	if m.Lesson.Name != "race" || len(m.Lesson.Lines) != 2 || m.Players[0].Total != 7 {
+		t.Fatalf("start = %+v", m)
+	}
+
+	// alice types the whole lesson, one mistake included
+	s := newSession(m.Lesson)
+	start := time.Now()
+	for _, r := range "ab cxef" {
+		s.addRune(r)
+		if s.lineFinished() {
+			s.finishLine()
+			s.advanceLine()
+		}
+	}
+	p := raceProgress(s, start)
+	if !p.Done || p.Typed != 7 || p.Errors != 1 {
+		t.Fatalf("progress = %+v, want done, 7 typed, 1 error", p)
+	}
+	c.report(p)
+	for {
+		m := waitFor(t, h, "players")
+		if m.Players[1].Done {
+			if m.Players[1].Place != 1 || m.Players[1].Errors != 1 {
+				t.Fatalf("alice = %+v", m.Players[1])
+			}
+			break
+		}
+	}
+
+	h.report(racePlayer{Typed: 7, Done: true})
+	end := waitFor(t, c, "end")
+	if len(end.Players) != 2 || end.Players[0].Name != "alice" || end.Players[1].Place != 2 {
+		t.Fatalf("ranking = %+v", end.Players)
+	}
+}
+
+func TestRaceClientLeaves(t *testing.T) {
+	h, err := newRaceHost("127.0.0.1:0", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.close()
+	c, err := dialRace(h.addr(), "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, h, "players")
+	h.start(&Lesson{Name: "race", Lines: []string{"x"}})
+	c.close()
+	h.report(racePlayer{Typed: 1, Done: true})
+	// bob's departure must not keep the race open
+	end := waitFor(t, h, "end")
+	if end.Players[0].Name != "host" || !end.Players[1].Left {
+		t.Fatalf("ranking = %+v", end.Players)
+	}
+	if _, err := dialRace(h.addr(), "late"); err != nil {
+		t.Fatalf("joining after the race ended: %v", err)
+	}
+}
+
+func TestRaceJoinDuringRace(t *testing.T) {
+	h, err := newRaceHost("127.0.0.1:0", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.close()
+	h.start(&Lesson{Name: "race", Lines: []string{"x"}})
+	if _, err := dialRace(h.addr(), "late"); err == nil {
+		t.Fatal("joined a race in progress")
+	}
+}
+
+func TestRaceFull(t *testing.T) {
+	h, err := newRaceHost("127.0.0.1:0", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.close()
+	for i := 1; i < raceMaxPlayers; i++ {
+		c, err := dialRace(h.addr(), fmt.Sprint("p", i))
+		if err != nil {
+			t.Fatalf("player %d: %v", i+1, err)
+		}
+		defer c.close()
+	}
+	if _, err := dialRace(h.addr(), "late"); err == nil {
+		t.Fatalf("player %d joined a full race", raceMaxPlayers+1)
+	}
+}
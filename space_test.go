@@ -0,0 +1,229 @@
+package main
+
+import "testing"
+
+// stepUntil advances g one tick at a time until cond holds or max ticks pass.
+func stepUntil(g *SpaceGame, max int, cond func() bool) int {
+	for i := 0; i < max; i++ {
+		if cond() {
+			return i
+		}
+		g.update()
+	}
+	return max
+}
+
+func TestSpaceGameSameSeedSameStream(t *testing.T) {
+	a, b := newSpaceGame(42), newSpaceGame(42)
+	for i := 0; i < 200; i++ {
+		a.update()
+		b.update()
+		if len(a.invaders) != len(b.invaders) {
+			t.Fatalf("tick %d: %d invaders vs %d", i, len(a.invaders), len(b.invaders))
+		}
+		for j := range a.invaders {
+			x, y := a.invaders[j], b.invaders[j]
+			if x.ch != y.ch || x.x != y.x || x.y != y.y || string(x.word) != string(y.word) {
+				t.Fatalf("tick %d: invader %d differs: %+v vs %+v", i, j, a.invaders[j], b.invaders[j])
+			}
+		}
+	}
+}
+
+func TestSpaceGameDifferentSeeds(t *testing.T) {
+	a, b := newSpaceGame(1), newSpaceGame(2)
+	for i := 0; i < 100; i++ {
+		a.update()
+		b.update()
+	}
+	same := len(a.invaders) == len(b.invaders)
+	for j := 0; same && j < len(a.invaders); j++ {
+		same = a.invaders[j].ch == b.invaders[j].ch && a.invaders[j].x == b.invaders[j].x
+	}
+	if same {
+		t.Fatal("seeds 1 and 2 produced identical alien streams")
+	}
+}
+
+func TestSpaceGameFirstSpawn(t *testing.T) {
+	g := newSpaceGame(7)
+	g.update()
+	if len(g.invaders) != 1 || g.spawned != 1 {
+		t.Fatalf("after one tick: %d invaders, %d spawned; want 1, 1", len(g.invaders), g.spawned)
+	}
+	inv := g.invaders[0]
+	if inv.ch < 'a' || inv.ch > 'z' {
+		t.Fatalf("invader letter %q not in a-z", inv.ch)
+	}
+	if inv.y != g.speed {
+		t.Fatalf("invader y = %v after one tick, want %v", inv.y, g.speed)
+	}
+}
+
+func TestSpaceGameShootLowest(t *testing.T) {
+	g := newSpaceGame(1)
+	g.invaders = []Invader{
+		{ch: 'a', x: 5, y: 2},
+		{ch: 'a', x: 9, y: 6},
+		{ch: 'b', x: 1, y: 8},
+	}
+	if !g.tryShoot('a') {
+		t.Fatal("tryShoot('a') missed")
+	}
+	if !g.invaders[1].dead || g.invaders[0].dead {
+		t.Fatalf("expected the lower 'a' to die: %+v", g.invaders)
+	}
+	if g.tryShoot('z') {
+		t.Fatal("tryShoot('z') hit with no 'z' on screen")
+	}
+	if g.score != 10 || g.hits != 1 {
+		t.Fatalf("score=%d hits=%d, want 10, 1", g.score, g.hits)
+	}
+	if g.waveKeys != 2 || g.waveGood != 1 {
+		t.Fatalf("waveKeys=%d waveGood=%d, want 2, 1", g.waveKeys, g.waveGood)
+	}
+}
+
+func TestSpaceGameMissCostsLife(t *testing.T) {
+	g := newSpaceGame(3)
+	g.spawned = g.waveSize // no more spawns
+	g.invaders = []Invader{{ch: 'q', x: 3, y: siFieldH - 0.1}}
+	g.update()
+	if g.lives != 2 || g.missed != 1 {
+		t.Fatalf("lives=%d missed=%d, want 2, 1", g.lives, g.missed)
+	}
+}
+
+func TestSpaceGameGameOver(t *testing.T) {
+	g := newSpaceGame(5)
+	n := stepUntil(g, 10000, func() bool { return g.gameOver })
+	if !g.gameOver {
+		t.Fatalf("no game over after %d idle ticks", n)
+	}
+	if g.lives != 0 {
+		t.Fatalf("lives = %d at game over, want 0", g.lives)
+	}
+	tick := g.tick
+	g.update()
+	if g.tick != tick {
+		t.Fatal("update advanced the clock after game over")
+	}
+}
+
+func TestSpaceGamePauseFreezes(t *testing.T) {
+	g := newSpaceGame(9)
+	g.update()
+	before := g.invaders[0].y
+	g.paused = true
+	for i := 0; i < 10; i++ {
+		g.update()
+	}
+	if g.invaders[0].y != before || g.tick != 1 {
+		t.Fatalf("paused game moved: y %v -> %v, tick %d", before, g.invaders[0].y, g.tick)
+	}
+}
+
+// clearWave shoots every invader as soon as it appears.
+func clearWave(t *testing.T, g *SpaceGame) {
+	t.Helper()
+	for i := 0; i < 10000 && !g.intermission; i++ {
+		g.update()
+		for _, inv := range g.invaders {
+			if inv.dead {
+				continue
+			}
+			if inv.boss() {
+				for _, r := range inv.word[inv.hit:] {
+					g.tryShoot(r)
+				}
+			} else {
+				g.tryShoot(inv.ch)
+			}
+		}
+		if g.gameOver {
+			t.Fatalf("game over during wave %d", g.level)
+		}
+	}
+	if !g.intermission {
+		t.Fatalf("wave %d never cleared", g.level)
+	}
+}
+
+func TestSpaceGameWaves(t *testing.T) {
+	g := newSpaceGame(11)
+	clearWave(t, g)
+	if g.waveHits != siWaveBase || g.waveMissed != 0 {
+		t.Fatalf("wave 1: hits=%d missed=%d, want %d, 0", g.waveHits, g.waveMissed, siWaveBase)
+	}
+	if g.waveBonus != 100 {
+		t.Fatalf("perfect wave 1 bonus = %d, want 100", g.waveBonus)
+	}
+	for g.level < siBossEvery-1 {
+		g.startWave(g.level + 1)
+		clearWave(t, g)
+	}
+
+	// boss wave: one long invader that absorbs its phrase rune by rune
+	g.startWave(g.level + 1)
+	if !bossWave(g.level) || g.waveSize != 1 {
+		t.Fatalf("wave %d: boss=%v size=%d", g.level, bossWave(g.level), g.waveSize)
+	}
+	g.update()
+	boss := g.invaders[0]
+	if !boss.boss() {
+		t.Fatal("boss wave spawned a regular invader")
+	}
+	if g.tryShoot(boss.word[0] + 1) {
+		t.Fatal("wrong rune damaged the boss")
+	}
+	for _, r := range boss.word {
+		if !g.tryShoot(r) {
+			t.Fatalf("boss rejected %q", r)
+		}
+	}
+	g.update()
+	if !g.intermission {
+		t.Fatal("boss wave did not end after the phrase was typed")
+	}
+}
+
+func TestSpaceGameGarbage(t *testing.T) {
+	a, b := newSpaceGame(21), newSpaceGame(21)
+	a.update()
+	b.update()
+	b.addGarbage(2)
+	if len(b.invaders) != 3 || !b.invaders[1].garbage {
+		t.Fatalf("after addGarbage(2): %+v", b.invaders)
+	}
+	// garbage must not shift the shared alien stream
+	for i := 0; i < 30; i++ {
+		a.update()
+		b.update()
+	}
+	var sa, sb []rune
+	for _, inv := range a.invaders {
+		sa = append(sa, inv.ch)
+	}
+	for _, inv := range b.invaders {
+		if !inv.garbage {
+			sb = append(sb, inv.ch)
+		}
+	}
+	if string(sa) != string(sb) {
+		t.Fatalf("alien streams diverged: %q vs %q", string(sa), string(sb))
+	}
+
+	// every siGarbagePer regular kills earn one garbage; garbage kills earn none
+	g := newSpaceGame(1)
+	for i := 0; i < siGarbagePer; i++ {
+		g.invaders = append(g.invaders, Invader{ch: 'a', x: i, y: 1})
+	}
+	g.invaders = append(g.invaders, Invader{ch: 'b', x: 9, y: 1, garbage: true})
+	for i := 0; i < siGarbagePer; i++ {
+		g.tryShoot('a')
+	}
+	g.tryShoot('b')
+	if g.outbox != 1 {
+		t.Fatalf("outbox = %d, want 1", g.outbox)
+	}
+}
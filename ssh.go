@@ -0,0 +1,203 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// SSH server — `tt serve-ssh [addr]`
+// ════════════════════════════════════════════════════════════════════
+
+const sshDefaultAddr = ":2222"
+
+// sshTerminal is the pty of one SSH session. Its size follows the
+// client's pty-req and window-change requests.
+type sshTerminal struct {
+	*keyReader
+	ch   ssh.Channel
+	mu   sync.Mutex
+	w, h int
+}
+
+func newSSHTerminal(ch ssh.Channel) *sshTerminal {
+	return &sshTerminal{keyReader: newKeyReader(ch), ch: ch, w: 80, h: 25}
+}
+
+func (t *sshTerminal) Write(p []byte) (int, error) { return t.ch.Write(p) }
+
+// Close stops reading keys and closes the session.
+func (t *sshTerminal) Close() error {
+	t.stop()
+	return t.ch.Close()
+}
+
+func (t *sshTerminal) Size() (int, int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.w, t.h, nil
+}
+
+func (t *sshTerminal) resize(w, h uint32) {
+	if w == 0 || h == 0 {
+		return
+	}
+	t.mu.Lock()
+	t.w, t.h = int(w), int(h)
+	t.mu.Unlock()
+}
+
+// defaultHostKeyPath is where serve-ssh keeps its host key unless
+// -hostkey says otherwise.
+func defaultHostKeyPath() string { return filepath.Join(dataDir(), "ssh_host_ed25519_key") }
+
+// loadHostKey reads the host key at path, generating and saving a new
+// ed25519 key the first time so clients see the same host every run.
+func loadHostKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		block, err := ssh.MarshalPrivateKey(priv, "tt host key")
+		if err != nil {
+			return nil, err
+		}
+		data = pem.EncodeToMemory(block)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKey(data)
+}
+
+// serveSSH runs `tt serve-ssh`: anyone can connect with a plain ssh
+// client and gets their own menu, lessons and games.
+func serveSSH(addr, keyPath string) error {
+	if addr == "" {
+		addr = sshDefaultAddr
+	}
+	signer, err := loadHostKey(keyPath)
+	if err != nil {
+		return fmt.Errorf("host key: %w", err)
+	}
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	fmt.Printf("Serving tt over SSH — connect with: ssh -p %s %s\n", port, localIP())
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go handleSSH(conn, config)
+	}
+}
+
+// handleSSH serves one SSH connection, which may open several sessions.
+func handleSSH(conn net.Conn, config *ssh.ServerConfig) {
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	sc, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	conn.SetDeadline(time.Time{})
+	defer sc.Close()
+	fmt.Printf("%s connected from %s\n", sc.User(), sc.RemoteAddr())
+	defer fmt.Printf("%s disconnected\n", sc.User())
+
+	go ssh.DiscardRequests(reqs)
+	var wg sync.WaitGroup
+	for nc := range chans {
+		if nc.ChannelType() != "session" {
+			nc.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+		ch, creqs, err := nc.Accept()
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sshSession(ch, creqs)
+		}()
+	}
+	wg.Wait()
+}
+
+// sshSession waits for the client to ask for a shell, then runs a menu
+// of its own on the session's terminal.
+func sshSession(ch ssh.Channel, reqs <-chan *ssh.Request) {
+	t := newSSHTerminal(ch)
+	defer t.Close()
+	shell, gone := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(gone)
+		started := false
+		for req := range reqs {
+			ok := false
+			switch req.Type {
+			case "pty-req":
+				var pty struct {
+					Term          string
+					Cols, Rows    uint32
+					Width, Height uint32
+					Modes         string
+				}
+				if ssh.Unmarshal(req.Payload, &pty) == nil {
+					t.resize(pty.Cols, pty.Rows)
+					ok = true
+				}
+			case "window-change":
+				var win struct{ Cols, Rows, Width, Height uint32 }
+				if ssh.Unmarshal(req.Payload, &win) == nil {
+					t.resize(win.Cols, win.Rows)
+					ok = true
+				}
+			case "shell", "exec":
+				// commands are ignored: every session gets the menu
+				ok = !started
+				if ok {
+					started = true
+					close(shell)
+				}
+			}
+			if req.WantReply {
+				req.Reply(ok, nil)
+			}
+		}
+	}()
+
+	select {
+	case <-shell:
+	case <-gone:
+		return
+	}
+	newUI(t, defaultSettings).run()
+	ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTerm is a Terminal driven by a test: keys are pressed from the
+// test and everything the ui writes is kept.
+type fakeTerm struct {
+	w, h   int
+	keys   chan keyEvent
+	closed chan struct{}
+	once   sync.Once
+
+	mu  sync.Mutex
+	out strings.Builder
+}
+
+func newFakeTerm() *fakeTerm {
+	return &fakeTerm{w: 80, h: 25, keys: make(chan keyEvent), closed: make(chan struct{})}
+}
+
+func (f *fakeTerm) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.out.Write(p)
+}
+
+func (f *fakeTerm) Size() (int, int, error) { return f.w, f.h, nil }
+func (f *fakeTerm) Events() <-chan keyEvent { return f.keys }
+
+func (f *fakeTerm) Close() error {
+	f.once.Do(func() { close(f.closed) })
+	return nil
+}
+
+// press sends each key and returns once the ui has finished with it:
+// keys are unbuffered, so the ui taking the empty key that follows means
+// it is waiting for input again. Keys pressed after the ui has quit are
+// dropped.
+func (f *fakeTerm) press(ks ...keyEvent) {
+	for _, k := range ks {
+		for _, k := range []keyEvent{k, {kind: evNone}} {
+			select {
+			case f.keys <- k:
+			case <-f.closed:
+				return
+			}
+		}
+	}
+}
+
+// typeText presses one character key per rune of s.
+func (f *fakeTerm) typeText(s string) {
+	for _, r := range s {
+		f.press(keyEvent{kind: evChar, ch: r, text: string(r)})
+	}
+}
+
+// output returns what the ui wrote since the previous call.
+func (f *fakeTerm) output() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.out.String()
+	f.out.Reset()
+	return s
+}
+
+// startRun runs the menu on a fake terminal. The returned channel
+// yields run's result; the terminal is closed when run returns.
+func startRun(t *testing.T) (*fakeTerm, <-chan error) {
+	t.Helper()
+	f := newFakeTerm()
+	done := make(chan error, 1)
+	go func() {
+		err := newUI(f, Settings{}).run()
+		f.Close()
+		done <- err
+	}()
+	return f, done
+}
+
+func waitRun(t *testing.T, done <-chan error) {
+	t.Helper()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not return")
+	}
+}
+
+func TestRunOnFakeTerminal(t *testing.T) {
+	f, done := startRun(t)
+	f.press(keyEvent{kind: evDown})
+	if out := f.output(); !strings.Contains(out, "Classic DOS TT") {
+		t.Errorf("menu not drawn: %q", out)
+	}
+	f.press(keyEvent{kind: evCtrlC})
+	waitRun(t, done)
+	if out := f.output(); !strings.Contains(out, "Goodbye!") {
+		t.Errorf("no goodbye: %q", out)
+	}
+}
+
+func TestKeyReader(t *testing.T) {
+	kr := newKeyReader(strings.NewReader("a"))
+	if k := <-kr.Events(); k.kind != evChar || k.ch != 'a' {
+		t.Errorf("got %+v, want a", k)
+	}
+	if k := <-kr.Events(); k.kind != evCtrlC {
+		t.Errorf("end of input gave %+v, want Ctrl-C", k)
+	}
+}
+
+func TestKeyReaderStop(t *testing.T) {
+	r, w := io.Pipe()
+	kr := newKeyReader(r)
+	w.Write([]byte("x"))
+	<-kr.Events()
+	kr.stop()
+	// the reader is blocked in Read; once that returns it must exit
+	// without delivering anything more
+	w.Write([]byte("y"))
+	w.Close()
+	select {
+	case k := <-kr.Events():
+		t.Errorf("got %+v after stop", k)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════
+// Versus Space Invaders — `tt versus` / `tt versus <addr>`
+// ════════════════════════════════════════════════════════════════════
+
+// siGarbagePer is how many regular aliens a player destroys to send
+// one garbage invader to the opponent; a boss sends siGarbageBoss.
+const (
+	siGarbagePer  = 3
+	siGarbageBoss = 2
+)
+
+// versusDefaultPort is used when `tt versus` gets no port. It differs
+// from the race and classroom ports so they can share a machine.
+const versusDefaultPort = "7779"
+
+// vsOpponent is what a versus game knows about the other player.
+type vsOpponent struct {
+	Name     string
+	Score    int
+	Lives    int
+	Level    int
+	Over     bool // their game is over: we won
+	Left     bool // they disconnected: we won by forfeit
+	Sent     int  // garbage we sent them
+	Received int  // garbage they sent us
+}
+
+// vsMsg is the versus wire format, one JSON object per line.
+type vsMsg struct {
+	Type  string `json:"type"` // "hello", "garbage", "status" or "closed"
+	Name  string `json:"name,omitempty"`
+	Seed  int64  `json:"seed,omitempty"`
+	N     int    `json:"n,omitempty"` // garbage invaders
+	Score int    `json:"score,omitempty"`
+	Lives int    `json:"lives,omitempty"`
+	Level int    `json:"level,omitempty"`
+	Over  bool   `json:"over,omitempty"`
+}
+
+// vsLink is a versus connection to the opponent.
+type vsLink struct {
+	conn net.Conn
+	enc  *json.Encoder
+	mu   sync.Mutex
+	msgs chan vsMsg
+	done chan struct{} // closed by close, to stop the reader
+	once sync.Once
+}
+
+func newVsLink(conn net.Conn, dec *json.Decoder) *vsLink {
+	v := &vsLink{conn: conn, enc: json.NewEncoder(conn), msgs: make(chan vsMsg, 64), done: make(chan struct{})}
+	go func() {
+		for {
+			var m vsMsg
+			err := dec.Decode(&m)
+			if err != nil {
+				m = vsMsg{Type: "closed"}
+			}
+			select {
+			case v.msgs <- m:
+			case <-v.done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return v
+}
+
+func (v *vsLink) send(m vsMsg) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
+	v.enc.Encode(m)
+}
+
+// close hangs up and stops the reader, whether or not anyone is still
+// taking its messages.
+func (v *vsLink) close() {
+	v.once.Do(func() {
+		close(v.done)
+		v.conn.Close()
+	})
+}
+
+// vsHandshake exchanges hellos on a fresh connection. The host sends
+// the seed; both sides learn the opponent's name.
+func vsHandshake(conn net.Conn, name string, seed int64, host bool) (*vsLink, vsMsg, error) {
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	enc := json.NewEncoder(conn)
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	defer conn.SetDeadline(time.Time{})
+	var hello vsMsg
+	if host {
+		if err := dec.Decode(&hello); err != nil {
+			return nil, hello, err
+		}
+		if err := enc.Encode(vsMsg{Type: "hello", Name: name, Seed: seed}); err != nil {
+			return nil, hello, err
+		}
+		hello.Seed = seed
+	} else {
+		if err := enc.Encode(vsMsg{Type: "hello", Name: name}); err != nil {
+			return nil, hello, err
+		}
+		if err := dec.Decode(&hello); err != nil {
+			return nil, hello, err
+		}
+	}
+	if hello.Type != "hello" {
+		return nil, hello, fmt.Errorf("unexpected %q from opponent", hello.Type)
+	}
+	return newVsLink(conn, dec), hello, nil
+}
+
+// versusGame plugs a networked SpaceGame into the game framework.
+// Opponent messages are applied on each tick.
+type versusGame struct {
+	*SpaceGame
+	link *vsLink
+	last vsMsg // last status sent
+}
+
+func newVersusGame(link *vsLink, hello vsMsg) *versusGame {
+	g := &versusGame{SpaceGame: newSpaceGame(hello.Seed), link: link}
+	g.versus = &vsOpponent{Name: hello.Name, Lives: 3, Level: 1}
+	return g
+}
+
+func (g *versusGame) ticker() Ticker { return newWallTicker(g.tickRate) }
+
+func (g *versusGame) update(u *ui) {
+	for drained := false; !drained; {
+		select {
+		case m := <-g.link.msgs:
+			g.apply(m)
+		default:
+			drained = true
+		}
+	}
+
+	missed := g.missed
+	g.SpaceGame.update()
+	if g.missed > missed {
+		u.bell()
+	}
+	if g.intermission {
+		// no breaks in versus: the next wave starts straight away
+		g.startWave(g.level + 1)
+	}
+	if g.outbox > 0 && !g.versus.Left {
+		g.link.send(vsMsg{Type: "garbage", N: g.outbox})
+		g.versus.Sent += g.outbox
+		g.outbox = 0
+	}
+	st := vsMsg{Type: "status", Score: g.score, Lives: g.lives, Level: g.level, Over: g.gameOver}
+	if st != g.last && !g.versus.Left {
+		g.link.send(st)
+		g.last = st
+	}
+}
+
+// apply folds one opponent message into the game.
+func (g *versusGame) apply(m vsMsg) {
+	switch m.Type {
+	case "garbage":
+		if !g.gameOver && !g.won() {
+			g.addGarbage(m.N)
+			g.versus.Received += m.N
+		}
+	case "status":
+		g.versus.Score, g.versus.Lives, g.versus.Level, g.versus.Over = m.Score, m.Lives, m.Level, m.Over
+	case "closed":
+		g.versus.Left = true
+	}
+}
+
+func (g *versusGame) render(u *ui, first bool) { u.renderSpaceGame(g.SpaceGame, first) }
+
+func (g *versusGame) handleKey(u *ui, k keyEvent) string {
+	switch {
+	case g.gameOver || g.won():
+		if k.kind == evEscape || k.kind == evChar && (k.ch == 'q' || k.ch == 'Q') {
+			return "quit"
+		}
+	case k.kind == evEscape:
+		return "quit" // forfeit
+	case k.kind == evChar:
+		for _, ch := range u.settings.remapKey(k).text {
+			if ch >= 'A' && ch <= 'Z' {
+				ch = ch - 'A' + 'a'
+			}
+			if !g.tryShoot(ch) {
+				u.bell()
+			}
+		}
+		g.render(u, false)
+	}
+	return ""
+}
+
+// vsPanel is the opponent status line drawn under the score bar.
+func vsPanel(o *vsOpponent) string {
+	lives := strings.Repeat("* ", max(o.Lives, 0))
+	state := ""
+	switch {
+	case o.Left:
+		state = FgRed + "  (left)" + RST + TTBg
+	case o.Over:
+		state = FgRed + "  (game over)" + RST + TTBg
+	}
+	return fmt.Sprintf("VS %s%s%s  Score:%s%5d%s  Wave:%s%d%s  Lives:%s%s%s  Garbage ▲%d ▼%d%s",
+		FgCyn+BOLD, o.Name, RST+TTBg,
+		FgYlw, o.Score, RST+TTBg,
+		FgCyn, o.Level, RST+TTBg,
+		FgRed, lives, RST+TTBg,
+		o.Sent, o.Received, state)
+}
+
+// playVersus runs `tt versus [addr]`: with no address, or one with no
+// host such as ":9000", it waits there for an opponent and picks the
+// seed; otherwise it joins addr.
+func playVersus(addr, name string) error {
+	return withConsole(func(u *ui, keys <-chan keyEvent) error {
+		defer u.goodbye()
+		var conn net.Conn
+		host := addr == "" || strings.HasPrefix(addr, ":")
+		if host {
+			ln, err := net.Listen("tcp", withDefaultPort(addr, versusDefaultPort))
+			if err != nil {
+				return err
+			}
+			defer ln.Close()
+			conns := make(chan net.Conn, 1)
+			go func() {
+				if c, err := ln.Accept(); err == nil {
+					conns <- c
+				}
+			}()
+			_, port, _ := net.SplitHostPort(ln.Addr().String())
+			u.renderVersusWait(net.JoinHostPort(localIP(), port))
+			for conn == nil {
+				select {
+				case conn = <-conns:
+				case k := <-keys:
+					if k.kind == evEscape || k.kind == evCtrlC || k.kind == evChar && (k.ch == 'q' || k.ch == 'Q') {
+						return nil
+					}
+				}
+			}
+		} else {
+			c, err := net.DialTimeout("tcp", withDefaultPort(addr, versusDefaultPort), 5*time.Second)
+			if err != nil {
+				return err
+			}
+			conn = c
+		}
+		link, hello, err := vsHandshake(conn, name, gameSeed(), host)
+		if err != nil {
+			conn.Close()
+			return errors.New("versus handshake failed: " + err.Error())
+		}
+		defer link.close()
+		u.runGame(keys, "", newVersusGame(link, hello))
+		return nil
+	})
+}
+
+func (u *ui) renderVersusWait(addr string) {
+	u.cls()
+	var b strings.Builder
+	b.WriteString(hTop() + "\n")
+	b.WriteString(hCenter(BOLD+TTTitle+"** SPACE INVADERS -- Versus **"+RST+TTBg) + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hCenter("Waiting for an opponent...") + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hCenter(TTDim+"They run: "+RST+TTBg+FgCyn+BOLD+"tt versus "+addr+RST+TTBg) + "\n")
+	b.WriteString(hBlank() + "\n")
+	b.WriteString(hMid() + "\n")
+	b.WriteString(hRow(TTDim+"ESC=Cancel"+RST+TTBg) + "\n")
+	b.WriteString(hBot() + "\n")
+	u.emit(padFrame(b.String()))
+}
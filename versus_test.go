@@ -0,0 +1,133 @@
+package main
+
+import (
+	"net"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// versusPair connects two versus games over loopback.
+func versusPair(t *testing.T) (host, guest *versusGame) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	type result struct {
+		link  *vsLink
+		hello vsMsg
+		err   error
+	}
+	done := make(chan result)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		link, hello, err := vsHandshake(conn, "ann", 99, true)
+		done <- result{link, hello, err}
+	}()
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gl, gh, err := vsHandshake(conn, "ben", 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := <-done
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if r.hello.Name != "ben" || gh.Name != "ann" || gh.Seed != 99 || r.hello.Seed != 99 {
+		t.Fatalf("handshake: host heard %+v, guest heard %+v", r.hello, gh)
+	}
+	host, guest = newVersusGame(r.link, r.hello), newVersusGame(gl, gh)
+	t.Cleanup(func() {
+		host.link.close()
+		guest.link.close()
+	})
+	return host, guest
+}
+
+// settle ticks g until cond holds.
+func settle(t *testing.T, g *versusGame, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(5 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("condition never held")
+		}
+		g.update(&ui{term: newFakeTerm()})
+	}
+}
+
+func TestVersusGarbageAndWin(t *testing.T) {
+	host, guest := versusPair(t)
+	host.outbox = 2
+	host.update(&ui{term: newFakeTerm()})
+	settle(t, guest, func() bool { return guest.versus.Received == 2 })
+	if host.versus.Sent != 2 {
+		t.Fatalf("host sent %d, want 2", host.versus.Sent)
+	}
+	n := 0
+	for _, inv := range guest.invaders {
+		if inv.garbage {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Fatalf("guest has %d garbage invaders, want 2", n)
+	}
+
+	guest.lives, guest.gameOver = 0, true
+	guest.update(&ui{term: newFakeTerm()})
+	settle(t, host, func() bool { return host.won() })
+	if host.versus.Lives != 0 || !host.versus.Over {
+		t.Fatalf("host's view of guest: %+v", host.versus)
+	}
+}
+
+func TestVersusOpponentLeaves(t *testing.T) {
+	host, guest := versusPair(t)
+	guest.link.close()
+	settle(t, host, func() bool { return host.versus.Left })
+	if !host.won() {
+		t.Fatal("opponent leaving should win the game")
+	}
+}
+
+func TestVersusGameOverFits(t *testing.T) {
+	term := newFakeTerm()
+	g := newSpaceGame(1)
+	g.versus = &vsOpponent{Name: "ben", Lives: 2, Score: 120, Level: 2}
+	g.gameOver = true
+	(&ui{term: term}).renderSpaceGame(g, false)
+	if n := strings.Count(term.output(), "\r\n"); n != boxH {
+		t.Fatalf("game over frame is %d lines, want %d", n, boxH)
+	}
+}
+
+func TestVersusCloseStopsReader(t *testing.T) {
+	host, guest := versusPair(t)
+	// flood the host with more than its queue holds, then leave without
+	// reading: close must still let the reader go
+	for i := 0; i < cap(host.link.msgs)+8; i++ {
+		guest.link.send(vsMsg{Type: "status", Score: i})
+	}
+	time.Sleep(50 * time.Millisecond)
+	host.link.close()
+	guest.link.close()
+	buf := make([]byte, 1<<20)
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		if !strings.Contains(string(buf[:runtime.Stack(buf, true)]), "newVsLink") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("a link's reader is still running after close")
+		}
+	}
+}
@@ -0,0 +1,609 @@
+package main
+
+// ════════════════════════════════════════════════════════════════════
+// Word lists — the most common words of a language, most common first
+// ════════════════════════════════════════════════════════════════════
+
+// enWords are the 5000 most common English words. Past the first 200
+// they follow the Wiktionary frequency list of TV and film subtitles
+// (CC BY-SA, as shipped with zxcvbn), less names, contractions,
+// interjections and profanity.
+var enWords = `
+the be to of and a in that have it for not on with he as you do at this
+but his by from they we say her she or an will my one all would there
+their what so up out if about who get which go me when make can like
+time no just him know take people into year your good some could them
+see other than then now look only come its over think also back after
+use two how our work first well way even new want because any these give
+day most us is was are were been has had did said made went got very
+many much more where here why should those while through down still each
+again off last long great little own old right big high different small
+large next early young important few public bad same able may such thing
+man woman child world life hand part place case week company system
+program question government number night point home water room mother
+area money story fact month lot book eye job word business issue side
+kind head house service friend father power hour game line end member
+law car going really mean tell yes something need never too sure sorry
+let am maybe anything doing thank thought help talk wait find nothing
+things call told before better ever away believe feel everything fine
+keep does put around stop guy always listen wanted guys happened thanks
+trying wrong talking being guess care mom remember getting together dad
+leave understand actually hear baby nice else stay done course might
+mind every enough try came someone family whole another yourself idea
+ask best must coming looking years left knew tonight real son hope name
+happy pretty saw girl sir show already saying three problem minute found
+thinking heard honey matter myself exactly having probably happen hurt
+boy both dead alone since excuse start kill hard today ready until
+without wants hold yet seen deal took once gone called morning supposed
+friends stuff used worry second live truth school face forget true cause
+soon knows telling wife chance run move anyone person somebody heart
+miss married later making meet anyway phone reason lost looks bring turn
+wish tomorrow kids trust check change late anymore five least town
+working makes taking means brother play hate ago says beautiful gave
+crazy party sit open afraid between rest fun kid watch glad everyone
+days sister minutes everybody bit couple either feeling daughter gets
+asked under break promise door set close easy tried far walk needs mine
+though times killed hospital anybody alright wedding shut die perfect
+stand comes hit waiting dinner against funny husband almost pay answer
+four office eyes news half yours moment sleep read started men sounds
+sonny pick sometimes bed date plan hours lose hands serious behind
+inside ahead wonderful fight past cut quite sick eat nobody goes along
+save seems finally lives worried upset met brought seem sort safe living
+children leaving front shot loved asking running clear figure hot felt
+six parents drink absolutely daddy alive sense meant happens special bet
+blood kidding lie full meeting dear seeing sound fault ten women buy
+months speak lady thinks body order outside hang possible worse mistake
+handle spend totally giving control marriage realize president unless
+send needed taken died scared picture talked hundred changed completely
+explain playing certainly sign boys relationship loves hair lying choice
+anywhere future weird luck turned known touch kiss crane questions
+obviously wonder pain calling somewhere throw straight cold fast words
+food none drive feelings worked marry light drop cannot sent city dream
+protect twenty class surprise sweetheart poor looked mad except gun
+dance takes appreciate especially situation besides pull himself act
+worth amazing top given expect rather involved swear piece busy decided
+happening movie catch country less perhaps step fall watching kept
+darling dog win air honor personal moving admit problems murder evil
+definitely feels information honest broke missed longer dollars tired
+evening human starting red entire trip club suppose calm imagine fair
+caught blame street sitting favor apartment court terrible clean learn
+works relax million accident wake prove smart message missing forgot
+interested table become mouth pregnant middle ring careful shall team
+ride figured wear shoot stick follow angry instead write stopped ran war
+standing forgive jail wearing lunch eight gotten hoping phoebe thousand
+ridge paper tough tape state count boyfriend proud agree birthday seven
+history share offer hurry feet wondering decision building ones finish
+voice herself list mess deserve evidence cute dress interesting hotel
+quiet concerned road staying beat sweetie mention clothes finished fell
+neither fix respect spent prison attention holding calls near surprised
+bar keeping gift putting dark self owe using ice helping normal aunt
+lawyer apart certain plans girlfriend floor whether present earth box
+cover judge upstairs sake mommy possibly worst station acting accept
+blow strange saved conversation plane mama yesterday lied quick lately
+stuck report difference rid store bag bought doubt listening walking
+cops deep dangerous sleeping record lord moved join card crime gentlemen
+willing window return walked guilty likes fighting difficult soul joke
+favorite uncle promised bother island seriously cell lead knowing broken
+advice somehow paid losing push helped killing usually earlier boss
+beginning liked innocent doc rules cop learned thirty risk letting
+speaking officer ridiculous support afternoon born apologize seat
+nervous across song charge patient boat hide detective planning nine
+huge breakfast horrible age awful pleasure driving hanging picked sell
+quit apparently dying notice congratulations chief visit letter decide
+double sad press forward fool showed smell seemed spell memory pictures
+slow seconds hungry board position hearing kitchen force fly during
+space realized experience kick others grab discuss third cat fifty
+responsible fat reading idiot suddenly agent destroy bucks track shoes
+scene peace arms demon low consider papers medical incredible witch
+attorney tells knock ways gives department nose turns keeps jealous drug
+sooner cares plenty extra tea won attack ground whose weekend matters
+wrote type gosh opportunity impossible books waste pretend named jump
+eating proof complete slept career arrest breathe perfectly warm pulled
+twice easier dating suit romantic drugs comfortable finds checked fit
+divorce begin ourselves closer ruin although smile laugh treat fear
+otherwise excited mail hiding cost stole pacey noticed fired excellent
+lived bringing pop bottom note sudden bathroom flight honestly sing foot
+games remind bank charges witness finding places tree dare hardly
+interest steal silly contact teach shop plus colonel fresh trial invited
+roll radio reach choose emergency dropped credit obvious cry locked
+loving positive nuts agreed goodbye condition guard grow cake mood total
+crying belong lay partner trick pressure arm dressed cup lies bus taste
+neck south nurse raise lots carry group whoever drinking breaking file
+lock wine closed writing spot paying study assume asleep turning legal
+bedroom shower camera fill reasons forty bigger breath doctors pants
+level movies gee folks ugh continue focus wild truly desk convince
+client threw band hurts spending allow grand answers shirt chair allowed
+rough sees ought empty round hat wind shows aware dealing pack meaning
+hurting ship subject guest pal match arrested confused surgery expecting
+deacon unfortunately lab passed bottle beyond whenever pool opinion held
+common starts jerk secrets falling played necessary barely dancing
+health tests copy cousin planned dry ahem twelve simply skin often
+fifteen speech names orders final results code believed complicated
+research nowhere escape biggest restaurant grateful usual burn address
+within someplace screw everywhere train film regret goodness mistakes
+details responsibility suspect corner hero dumb terrific further gas
+hole memories following ended teeth ruined split airport bite older liar
+showing project cards desperate themselves pathetic damage spoke quickly
+scare afford vote settle mentioned due stayed rule checking tie hired
+upon heads concern blew natural champagne connection tickets happiness
+form saving kissing hated personally suggest prepared build leg onto
+leaves downstairs ticket taught loose holy staff sea duty convinced
+throwing defense kissed legs according loud practice babies army warning
+miracle carrying flying blind ugly shopping hates sight bride coat
+account states clearly celebrate brilliant wanting add lips custody
+center screwed buying size toast thoughts student stories however
+professional reality birth attitude advantage grandfather sold opened
+grandma beg changes someday grade roof brothers signed marrying powerful
+grown grandmother fake opening expected eventually ideas exciting
+covered familiar bomb television harmony color heavy schedule records
+capable practically including correct clue forgotten immediately
+appointment social nature deserves threat lonely ordered shame local
+jacket hook destroyed scary investigation above invite shooting port
+lesson criminal growing caused victim professor followed funeral
+considering burning strength loss view sisters several pushed written
+shock pushing heat chocolate greatest miserable nightmare brings
+character became famous enemy crash chances sending recognize healthy
+boring feed engaged percent headed lines treated purpose knife rights
+drag fan badly hire paint pardon built behavior closet warn gorgeous
+milk survive forced operation offered ends dump rent remembered
+lieutenant trade thanksgiving rain revenge physical available prefer
+spare pray disappeared aside statement sometime meat fantastic breathing
+laughing itself tip stood market affair ours depends main protecting
+jury national brave interview fingers murdered explanation process
+picking based style pieces blah assistant stronger aah pie handsome
+unbelievable anytime nearly shake cars wherever serve pulling points
+medicine facts waited lousy circumstances stage disappointed weak
+trusted license community trash understanding slip cab sounded awake
+friendship stomach weapon threatened mystery official regular river
+understood contract race basically switch frankly issues cheap lifetime
+deny painting ear clock weight garbage tear ears dig selling setting
+indeed changing singing tiny particular draw decent avoid messed filled
+touched score disappear exact pills kicked harm recently fortune
+pretending raised insurance fancy drove cared belongs nights shape base
+lift stock fashion timing guarantee chest bridge woke source patients
+theory original burned watched heading selfish oil drinks failed period
+doll committed elevator freeze noise exist science pair edge wasting sat
+ceremony pig uncomfortable peg guns staring files bike weather mostly
+stress permission arrived thrown possibility example borrow release ate
+notes library property negative fabulous event doors screaming term meal
+fellow apology anger honeymoon wet bail parking non protection fixed
+families campaign map wash stolen sensitive stealing chose lets comfort
+worrying whom pocket bleeding students shoulder ignore fourth
+neighborhood talent tied garage dies demons dumped witches training rude
+crack model bothering radar grew remain soft meantime connected kinds
+cast sky likely fate buried hug concentrate prom messages east unit
+intend crew ashamed manage guilt weapons terms interrupt guts tongue
+distance conference treatment shoe basement sentence purse glasses cabin
+universe towards repeat mirror wound tall reaction odd engagement
+therapy letters emotional runs magazine jeez decisions soup thrilled
+society managed stake chef moves extremely entirely moments expensive
+counting shots kidnapped square cleaning shift plate impressed smells
+trapped male tour knocked charming attractive argue puts whip language
+embarrassed settled package laid animals hitting disease bust stairs
+alarm pure nail nerve incredibly walks dirt stamp becoming terribly
+friendly easily jobs suffering disgusting stopping deliver riding helps
+federal disaster bars crossed rate create trap claim talks eggs effect
+chick threatening spoken introduce confession embarrassing bags
+impression gate reputation attacked among knowledge presents inn chat
+suffer argument crowd homework fought coincidence cancel accepted rip
+pride solve hopefully pounds pine mate illegal generous streets con
+separate outfit maid bath punch mayor freaked begging recall enjoying
+bug prepare parts wheel signal direction defend signs painful yourselves
+rat amount suspicious flat cooking button warned sixty pity parties
+crisis coach row yelling leads awhile pen confidence offering falls
+image farm pleased panic hers role refuse determined grandpa progress
+testify passing military choices gym cruel wings bodies mental gentleman
+coma cutting guests expert benefit faces cases led jumped toilet
+secretary sneak mix firm agreement privacy dates anniversary smoking
+reminds pot created twins swing successful season scream considered
+solid options commitment senior ill crush ambulance wallet discovered
+officially til rise reached eleven option laundry former assure stays
+skip fail accused wide challenge popular learning discussion clinic
+plant exchange betrayed bro sticking university members lower bored
+mansion soda sheriff suite handled busted senator load happier younger
+studying romance procedure ocean section sec commit assignment suicide
+minds swim ending bat yell league chasing seats proper command believes
+humor hopes fifth winning solution leader sale lawyers nor material
+latest highly escaped audience parent tricks insist dropping cheer
+medication higher flesh district routine century shared sandwich handed
+false beating appear warrant awfully odds article treating thin
+suggesting fever sweat silent specific clever sweater request prize mall
+tries mile fully estate union sharing assuming judgment goodnight
+divorced despite surely steps jet confess math listened answered
+vulnerable bless dreaming rooms chip zero potential kills tears knees
+chill brains agency degree unusual joint packed dreamed cure covering
+newspaper coast grave egg direct cheating breaks quarter mixed locker
+gifts awkward toy rare policy joking competition classes assumed
+reasonable dozen curse millions dessert rolling detail alien served
+delicious closing vampires released ancient wore value tail secure salad
+murderer hits toward spit screen offense dust conscience bread answering
+admitted lame invitation grief smiling path stands bowl pregnancy
+prisoner delivery guards virus shrink influence freezing concert wreck
+partners chain birds wire technically presence blown anxious cave
+version holidays cleared wishes survived caring candles bound related
+charm pulse jumping jokes frame boom vice performance occasion silence
+opera nonsense frightened downtown slipped blowing session relationships
+kidnapping actual spin civil packing education blaming wrap obsessed
+fruit torture personality location effort commander trees owner fairy
+per necessarily county contest seventy print motel fallen directly
+underwear grams exhausted believing particularly freaking carefully
+trace touching messing committee recovery intention consequences belt
+sacrifice courage officers enjoyed lack attracted appears bay yard
+returned remove nut carried testimony intense granted violence heal
+defending attempt unfair relieved political loyal approach slowly plays
+normally buzz alcohol actor surprises psychiatrist plain attic uniform
+terrified sons pet cleaned threaten teaching mum motion fella enemies
+desert collection incident failure satisfied imagination hooked headache
+forgetting counselor acted opposite highest equipment badge visiting
+naturally frozen commissioner labor appropriate trunk armed thousands
+received costume temporary sixteen impressive zone kicking junk hon
+grabbed unlike understands describe clients owns affect witnesses
+starving instincts happily discussing deserved strangers leading
+intelligence host authority surveillance cow commercial admire
+questioning fund dragged barn object deeply amp wrapped wasted tense
+route reports hoped fellas election roommate mortal fascinating chosen
+stops shown arranged abandoned sides delivered becomes arrangements
+agenda began theater series literally propose honesty underneath forces
+services sauce promises lecture eighty torn shocked relief explained
+counter circle victims transfer response channel identity differently
+campus spy ninety interests guide deck biological ease creep waitress
+skills telephone ripped raising scratch rings prints wave thee arguing
+figures asks reception pin diner annoying agents goal mass ability
+sergeant international gig blast basic tradition towel earned rub habit
+customers creature actions snap react prime paranoid handling eaten
+therapist comment charged tax sink reporter beats priority interrupting
+gain fed warehouse shy pattern loyalty inspector events pleasant media
+excuses threats permanent guessing financial demand assault tend praying
+motive unconscious trained museum tracks range nap mysterious unhappy
+tone switched award neighbor loaded gut childhood causing swore hundreds
+balance background toss mob misery thief squeeze lobby exercise ego
+drama forth facing booked boo songs eighteen bury perform everyday
+digging creepy compared wondered trail liver drawn device magical
+journey fits discussed supply moral helpful attached searching flew
+depressed aisle underground pro daughters amen vows proposal pit
+neighbors darn cents arrange annulment uses useless squad represent
+product joined afterwards adventure resist protected net fourteen
+celebrating piano inch flag debt violent tag sand gum dammit hip
+celebration below reminded claims replace phones paperwork emotions
+typical stubborn stable pound papa lap designed current bum tension tank
+suffered steady provide overnight meanwhile chips beef wins suits boxes
+salt collect tragedy therefore spoil realm profile degrees wipe surgeon
+stretch stepped nephew neat limo confident anti perspective designer
+climb title suggested punishment finest occurred hint furniture blanket
+twist surrounded surface proceed lip fries worries refused niece gloves
+soap signature disappoint crawl convicted zoo result pages lit flip
+counsel doubts crimes accusing shaking remembering phase hallway halfway
+bothered useful makeup madam gather concerns cameras blackmail symptoms
+rope ordinary imagined concept cigarette supportive memorial explosion
+woo trauma ouch furious cheat avoiding whew thick boarding approve
+urgent misunderstanding minister drawer sin phony joining jam interfere
+governor chapter catching bargain tragic schools respond punish
+penthouse hop thou remains insult bugs beside begged absolute strictly
+socks senses ups sneaking serving reward polite checks tale physically
+instructions fooled blows tabby internal bitter adorable tested
+suggestion string jewelry debate com alike pitch fax distracted shelter
+lessons foreign average twin constable circus audition tune shoulders
+mud mask helpless feeding explains dated robbery objection behave
+valuable shadows courtroom confusing tub talented struck smarter
+mistaken customer bizarre scaring punk holds focused alert activity
+reverend highway foolish compliment attend scheme aid worker wheelchair
+protective poetry gentle script reverse picnic knee intended
+construction cage voices toes stink scares pour effects cheated tower
+slide ruining recent filling exit cottage corporate upside supplies
+proves parked instance grounds diary complaining basis wounded politics
+confessed pipe merely massage data chop budget brief spill prayer costs
+betray begins arrangement waiter scam rats fraud flu brush adopted
+tables sympathy pill pee web seventeen landed expression entrance
+employee drawing cap bracelet principal pays fairly facility deeper
+arrive unique tracking spite shed recommend nanny naive menu grades diet
+corn authorities separated roses patch dime devastated description tap
+subtle include citizen bullets beans pile executive confirm toe strings
+parade harbor bow borrowed toys straighten steak status remote
+premonition poem planted honored youth specifically meetings exam
+convenient traveling matches laying insisted apply units technology dish
+sis kindly grandson donor temper teenager strategy proven iron denial
+couples backwards tent swell noon happiest episode drives spirits potion
+fence affairs acts whatsoever rehearsal proved overheard nuclear hostage
+faced constant bench taxi shove sets moron limits impress entitled
+needle limit lad intelligent instant forms disagree stinks recover
+losers groom gesture developed constantly blocks bartender tunnel
+suspects sealed removed legally illness hears dresses aye vehicle thy
+teachers sheet receive psychic denied knocking judging bible behalf
+accidentally waking ton superior seek rumor manners homeless hollow
+desperately critical theme tapes referring personnel item gear majesty
+fans exposed cried tons spells producer launch instinct belief quote
+motorcycle convincing appeal advance greater fashioned aids accomplished
+grip bump upsetting soldiers scheduled production needing invisible
+forgiveness feds complex compare bothers tooth territory sacred inviting
+inner earn compromise cocktail tramp temperature signing landing jabot
+intimate dignity dealt souls informed gods entertainment dressing
+cigarettes blessing billion upper manner lightning leak fond alternative
+seduce players operate modern liquor fingerprints enchantment butters
+stuffed filed emotionally division conditions transplant tips passes
+oxygen nicely lunatic hid drill designs complain announcement visitors
+unfortunate slap prayers plug organization opens oath mutual graduate
+confirmed broad yacht spa remembers fried extraordinary bait appearance
+abuse sworn stare safely reunion plot burst aha experiment dive
+commission cells aboard returning independent expose environment buddies
+trusting smaller mountains booze sweep sore properly parole effective
+ditch decides canceled bra speaks reaching glow foundation wears thirsty
+skull ringing dorm dining bend unexpected systems sob pancakes harsh
+flattered existence troubles proposed fights eats driven computers rage
+causes border undercover spoiled shine rug identify destroying deputy
+deliberately conspiracy clothing thoughtful similar sandwiches plates
+nails miracles investment fridge drank contrary beloved allergic washed
+stalking solved sack misses forgiven bent approval practical organized
+involve industry fuel dragging cooked possession pointing foul editor
+dull beneath ages horror heels grass faking deaf stunt portrait painted
+jealousy hopeless fears cuts conclusion volunteer scenario satellite
+necklace crashed chapel accuse restraining humans homicide helicopter
+formal firing shortly safer devoted auction videotape tore stores
+reservations pops appetite wounds vanquish symbol prevent patrol ironic
+flow fathers excitement anyhow tearing sends laughed function core
+charmed sub dealer cooperate bachelor accomplish wakes struggle spotted
+sorts reservation ashes yards votes tastes supposedly loft intentions
+integrity wished towels suspected slightly qualified log investigating
+inappropriate immediate companies backed pan owned lipstick lawn
+compassion cafeteria belonged affected scarf precisely obsession
+management loses lighten infection granddaughter explode chemistry
+balcony storage spying publicity exists employees depend cue cracked
+conscious ally ace accounts absurd vicious tools strongly rap invented
+forbid directions defendant bare announce screwing salesman robbed leap
+insanity injury genetic document reveal religious possibilities kidnap
+gown entering chairs wishing statue setup serial punished dramatic
+dismissed criminals seventh regrets quarters produce lamp dentist
+anyways anonymous added semester risks regarding owes magazines machines
+lungs explaining delicate tricked oldest eager doomed cafe bureau
+adoption traditional surrender stab sickness scum loop independence
+generation floating envelope entered combination chamber worn vault
+pretended potatoes plea photograph payback misunderstood kiddo healing
+cascade application stabbed remarkable cabinet brat wrestling sixth
+scale privilege passionate nerves lawsuit kidney disturbed crossing cozy
+associate tire shirts required posted oven ordering mill journal gallery
+delay clubs risky nest monsters honorable grounded culture closest
+breakdown attempted placed conflict bald actress abandon steam scar pole
+duh collar worthless standards resources photographs introduced injured
+graduation enormous disturbing disturb distract deals conclusions vodka
+situations require mid measure dishes crawling congress briefcase wiped
+whistle sits roast rented pigs flirting existed deposit damaged bottles
+types topic riot overreacting minimum logical impact hostile embarrass
+casual beacon amusing altar values recognized maintain goods covers
+battery survival skirt shave prisoners porch med ghosts favors drops
+dizzy chili begun beaten advise transferred strikes rehab raw
+photographer peaceful leery heavens fortunately fooling expectations
+draft citizens weakness ski ships ranch practicing musical movement
+individual homes executed examine documents cranes column bribe task
+species sail rum resort prescription operating hush fragile forensics
+expense drugged differences cows conduct comic bells avenue attacking
+assigned visitor suitcase sources scan payment motor mini inspired
+insecure imagining hardest clerk yea wrist tube starters silk pump pale
+nicer haul flies demands boot arts limited elders connections quietly
+pulls idiots factor erase denying attacks ankle amnesia accepting
+heartbeat gal confront backing phrase operations minus meets legitimate
+hurricane fixing communication boats auto arrogant supper studies
+slightest sins recipe pier paternity humiliating genuine catholic snack
+rational pointed minded guessed display dip advanced weddings tumor
+teams reported humiliated destruction copies closely bid aspirin academy
+wig throughout spray occur logic eyed equal drowning contacts ritual
+perfume hiring hating generally error elected docks creatures visions
+thanking thankful sock replaced nineteen fork comedy analysis throws
+teenagers studied stressed slice rolls requires plead ladder kicks
+detectives assured widow tissue shallow responsibilities repay rejected
+permanently girlfriends deadly comforting ceiling bonus verdict
+maintenance jar insensitive factory aim triple spilled respected
+recovered messy interrupted bleed benefits wardrobe significant
+objective murders chart backs workers waves underestimate ties
+registered multiple justify harmless frustrated fold convention
+communicate bugging attraction arson whack salary rumors residence
+obligation medium liking development develop dearest congratulate
+vengeance severe rack puzzle guidance fires courtesy caller blamed tops
+repair quiz prep involves headquarters curiosity codes circles barbecue
+troops spinning scores pursue psychotic cough claimed accusations shares
+resent laughs gathered freshman envy drown sofa scientist poster islands
+highness dock apologies welfare theirs stat stall spots somewhat
+realizes psych fools finishing album wee understandable unable treats
+succeed stir relaxed inches gratitude faithful bin accent zip witter
+wandering regardless locate inevitable deed crushed controlling taxes
+smelled settlement robe poet opposed marked gossip gambling determine
+cosmetics cent accidents surprising stiff sincere shield rushed resume
+reporting refrigerator reference preparing nightmares ignoring hunch fog
+fireworks drowned crown cooperation brass accurate whispering
+sophisticated religion luggage investigate hike explore emotion creek
+crashing contacted complications acid shining rolled righteous
+reconsider inspiration goody geek frightening festival ethics creeps
+courthouse camping assistance affection vow protest lodge haircut
+forcing essay chairman baked apologized vibe respects receipt includes
+hats exclusive destructive define defeat adore adopt voted tracked
+signals shorts reminding relative ninth floors dough creations continues
+barrel slight reporters rear pressing novel newspapers magnificent
+madame lazy glorious fiancee candidate brick bits activities visitation
+scholarship sane previous kindness rescued mattress lounge lifted label
+importantly glove enterprises disappointment condo cemetery beings
+admitting yelled waving screech satisfaction requested reads plants nun
+nailed described dedicated certificate centuries annual worm tick
+resting primary polish marvelous fuss funds defensive compete chased
+provided pockets luckily filing depression conversations consideration
+consciousness worlds innocence indicate forehead appeared aggressive
+trailer slam retirement quitting pry narrow levels inform encourage dug
+delighted daylight danced currently confidential aunts washing tossed
+spectra permit marrow lined implying hatred grill efforts corpse clues
+sober relatives promotion offended morgue larger infected humanity
+electricity electrical distraction cart broadcast wired violation
+suspended promising harassment glue gathering cursed controlled calendar
+brutal assets warlocks wagon unpleasant proving priorities observation
+lease grows flame domestic disappearance depressing thrill sitter ribs
+offers flush exception earrings deadline corporal collapsed update
+snapped smack offices melt figuring delusional burnt actors trips tender
+sperm specialist scientific pork popped planes interrogation institution
+included esteem communications choosing choir undo pres prayed plague
+manipulate lifestyle insulting detention delightful coffeehouse chess
+betrayal apologizing adjust wrecked wont whipped rides reminder
+psychological principle monsieur injuries fame faint confusion bake
+nearest industries execution distress definition creating correctly
+complaint blocked trophy tortured structure rot risking pointless
+household heir handing eighth dumping cups alibi absence vital thus
+struggling shiny risked refer mummy mint involvement hose hobby
+fortunate fitting curtain counseling addition wit transport technical
+rode puppet opportunities modeling memo irresponsible humiliation hiya
+fez felony choke blackmailing appreciated tabloid suspicion recovering
+rally psychology pledge panicked nursery louder jeans investigator
+identified homecoming height graduated frustrating fabric distant buys
+busting buff wax sleeve products philosophy irony hospitals dope declare
+autopsy torch substitute scandal prick limb leaf hysterical growth fetch
+dimension crowded clip climbing bonding approved ultimately trusts
+returns negotiate millennium majority lethal length iced deeds bore
+babysitter questioned outrageous medal insulted grudge established
+driveway deserted definite capture beep wires suggestions searched owed
+originally nickname lighting lend demanding conviction characters bumped
+weigh touches tempted shout resolve relate poisoned pip occasionally
+meals maker invitations haunted fur footage depending bogus autograph
+affects tolerate stepping spontaneous sleeps probation presentation
+performed identical fist cycle associates streak spectacular sector
+lasted increase hostages heroin habits encouraging cult consult burgers
+boyfriends bailed baggage association wealthy watches versus troubled
+torturing teasing sweetest stations sip rag qualities postpone pad
+overwhelmed impulse hut follows classy charging amazed scenes rising
+revealed representing policeman offensive mug hypocrite humiliate
+hideous finals experiences courts costumes captured bluffing betting
+bedtime alcoholic vegetable tray suspicions spreading splendid shouting
+roots pressed intent grieving gladly fling eliminate disorder cereal
+arrives yum technique statements servant roads republican paralyzed orb
+locks guaranteed dummy discipline despise dental corporation carries
+briefing bluff batteries atmosphere tux sounding servants rifle presume
+handwriting goals gin fainted elements dried cape allowing acknowledge
+whacked toxic skating reliable quicker penalty panel overwhelming nearby
+lining importance harassing fatal endless elsewhere dolls convict bold
+ballet unlikely spiritual shutting separation recording positively
+overcome failing essence dose diagnosis cured claiming bully airline
+yearbook various tempting shelf rig pursuit prosecution pouring
+possessed partnership countries wonders thorough spine psychiatric
+meaningless latte jammed ignored fiance exposure exhibit evidently
+duties contempt compromised capacity cans weekends urge theft suing
+shipment scissors responding refuses proposition noises matching located
+ink hormones hail grandchildren godfather gently establish contracts
+compound worldwide smashed sentimental senor scored nicest marketing
+manipulated jaw intern handcuffs framed errands entertaining discovery
+crib carriage barge awards attending ambassador videos tab spends
+slipping seated rubbing rely reject recommendation reckon ratings
+headaches float embrace corners whining sweating sole skipped restore
+receiving population pep motives listens heroes controls cheerleader
+unnecessary stunning shipping scent praise pose luxury loosen info hum
+haunt gracious git forgiving fleet errand emperor cakes blames abortion
+worship theories strict sketch shifts plotting physician perimeter
+passage pals mere mattered longest interference eyewitness enthusiasm
+encounter diapers artists strongest shaken serves punched projects
+portal outer colleagues catches bearing backyard academic winds
+terrorists sabotage pea organs needy mentor measures listed cuff
+civilization articles writes valid rarely rabbi prank performing
+obnoxious mates improve hereby gabby faked cellar void substance
+strangle sour skill senate purchase native muffins interfering demonic
+colored clearing civilian buildings boutique trading terrace smoked seed
+relations published preliminary pact outstanding opinions knot ketchup
+items examined disappearing coin circuit assist administration uptight
+ticking terrifying tease swamp secretly rejection reflection realizing
+rays partly mentally jurisdiction doubted deception crucial congressman
+cheesy arrival visited supporting stalling scouts scoop ribbon reserve
+raid notion income immune expects edition destined constitution
+classroom bets appreciation appointed accomplice wander shoved sewer
+scroll retire paintings lasts fugitive freezer discount cranky crank
+clearance bodyguard anxiety accountant volunteered terrorist tales
+talents stinking resolved remotely protocol garlic decency cord beds
+areas altogether uniforms tremendous restaurants rank profession popping
+observe lung largest hangs experts enforcement encouraged economy dudes
+donation disguise curb continued competitive businessman bites antique
+advertising ads toothbrush retreat represents realistic profits predict
+lid landlord hourglass hesitate focusing equally consolation babbling
+aged tipped stranded smartest rhythm replacement repeating puke paycheck
+overreacted macho leadership juvenile images grocery freshen disposal
+cuffs consent caffeine arguments agrees vanished unfinished tobacco tin
+syndrome ripping pinch missiles isolated flattering expenses dinners
+colleague ciao attorneys whereabouts wars visits truce tripped tee
+tasted steer ruling poisoning nursing manipulative immature husbands
+heel granddad delivering deaths automatically anchor trashed tournament
+throne raining prices pasta needles leaning leaders judges ideal
+detector coolest casting batch approximately appointments almighty
+achieve vegetables sum spark ruled revolution principles perfection
+pains mole interviews initiative hairs getaway employment den cracking
+counted compliments behold verge tougher timer tapped taped stakes
+specialty snooping shoots semi rendezvous pentagon passenger leverage
+jeopardize janitor grandparents forbidden examination communist clueless
+cities bidding arriving adding ungrateful unacceptable tutor soviet
+shaped serum savings pub pajamas mouths modest methods lure irrational
+depth cries classified bombs beautifully arresting approaching vessel
+variety traitor sympathetic smug smash rental premonitions mild jumps
+inventory improved developing committing banging asap amendment worms
+violated vent traumatic traced tow sweaty shaft recommended overboard
+literature insight healed grasp fluid experiencing crab chunk applied
+witnessed traveled stain shack reacted pronounce presented poured
+occupied moms marriages invested handful gob gag flipped fireplace
+expertise embarrassment disappears concussion bruises brakes twisting
+tide swept summon splitting settling scientists reschedule regard
+purposes notch improvement hooray grabbing extend exquisite disrespect
+complaints armor voting sustained straw slapped shipped shattered
+ruthless refill recorded payroll numb mourning manly involving hunk
+entertain earthquake drift dreadful doorstep confirmation chops
+appreciates announced vague tires stressful stem stashed stash sensed
+preoccupied predictable noticing madly halls gunshot embassy dozens
+confuse cleaners charade chalk cappuccino breed bouquet amulet addiction
+warming unlock transition satisfy sacrificed relaxing
+`
+
+// esWords are the 200 most common Spanish words.
+var esWords = `
+de la que el en y a los se del las un por con no una su para es al lo
+como más pero sus le ya o este sí porque esta entre cuando muy sin sobre
+también me hasta hay donde quien desde todo nos durante todos uno les ni
+contra otros ese eso ante ellos e esto mí antes algunos qué unos yo otro
+otras otra él tanto esa estos mucho quienes nada muchos cual poco ella
+estar estas algunas algo nosotros mi mis tú te ti tu tus ellas nosotras
+vosotros os mío mía tuyo suyo nuestro nuestra vuestro esos esas estoy
+estás está estamos están ser soy eres somos son era fue fueron sido
+tener tengo tiene tenemos tienen tenía hacer hago hace hacen hizo decir
+dijo dice puede pueden poder ir voy va vamos van ver veo ve dar da dan
+saber sé sabe querer quiero quiere llegar pasar deber debe poner parecer
+quedar creer hablar llevar dejar seguir encontrar llamar venir pensar
+salir volver tomar conocer vivir sentir tratar mirar contar empezar
+esperar buscar existir entrar trabajar escribir perder producir ocurrir
+entender pedir recibir recordar terminar permitir aparecer conseguir
+comenzar servir sacar necesitar mantener
+`
+
+// frWords are the 200 most common French words.
+var frWords = `
+de la le et les des en un du une que est pour qui dans par plus pas au
+sur ne se il elle je tu nous vous ils elles on ce cette ces son sa ses
+leur leurs mon ma mes ton ta tes notre votre nos vos mais ou où donc or
+ni car avec sans sous entre vers chez comme si tout tous toute toutes
+autre autres même aussi bien très peu trop encore déjà toujours jamais
+souvent ici là alors puis ensuite après avant pendant depuis quand
+comment pourquoi quoi quel quelle lui moi toi eux y être suis es sommes
+êtes sont était été avoir ai as avons avez ont avait eu faire fait font
+faisait dire dit aller va vont allé voir vu savoir sait pouvoir peut
+peuvent vouloir veut venir vient devoir doit prendre pris mettre mis
+donner donné parler trouver passer croire penser aimer falloir faut
+rester partir sortir sentir vivre connaître rendre comprendre attendre
+entendre tenir porter laisser arriver demander montrer jouer écrire lire
+ouvrir répondre perdre suivre chercher temps année jour fois chose homme
+femme enfant vie monde maison pays main tête oeil yeux porte eau nuit
+moment place point
+`
+
+// deWords are the 200 most common German words; nouns keep their capital.
+var deWords = `
+der die und in den von zu das mit sich des auf für ist im dem nicht ein
+eine als auch es an werden aus er hat dass sie nach wird bei einer um am
+sind noch wie einem über einen so zum war haben nur oder aber vor zur
+bis mehr durch man sein wurde sei ihr ich du wir uns euch ihm ihn mich
+dich mir dir schon wenn was wo wer warum wieder immer jetzt hier dort da
+dann denn doch ja nein kein keine viel viele sehr ganz gut neu alt groß
+klein lang kurz hoch erste letzte andere alle alles jeder jede dieser
+diese dieses welche mein dein unser euer seine ihre meine heute morgen
+gestern Zeit Jahr Tag mal leben Welt Haus Hand Mann Frau Kind Land Stadt
+weg Teil Fall Arbeit Frage Geld Wasser Auge Kopf Tür Nacht Sache Mensch
+Menschen gehen kommen sagen machen geben sehen wissen können müssen
+sollen wollen dürfen stehen finden bleiben liegen nehmen halten bringen
+denken lassen heißen zeigen führen sprechen spielen glauben schreiben
+lesen fragen antworten laufen fahren essen trinken schlafen arbeiten
+lernen kaufen helfen verstehen beginnen hören fallen gewinnen verlieren
+tragen ziehen setzen öffnen warten gegen
+`